@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xieburoucoco/spider-hub/platforms"
+	"github.com/xieburoucoco/spider-hub/platforms/amazon"
+	"github.com/xieburoucoco/spider-hub/proxy"
+)
+
+// runAmazon 执行 amazon 子命令
+//
+//	spider-hub amazon product <url|ASIN> [--marketplace JP] [--rules rules.json]
+//	spider-hub amazon image-search --file <path> | --url <imageURL> [--marketplace JP]
+func runAmazon(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: 用法 spider-hub amazon product|image-search ...", errUsage)
+	}
+
+	var common commonFlags
+	fs := newFlagSet("amazon "+args[0], &common)
+	imageFile := fs.String("file", "", "本地图片路径（image-search）")
+	imageURL := fs.String("url", "", "在线图片URL（image-search）")
+	marketplace := fs.String("marketplace", "US", "站点代码或域名，如 JP、amazon.de（image-search 和按ASIN获取商品；商品URL按域名自动识别）")
+	rulesFile := fs.String("rules", "", "商品详情页解析规则文件（JSON），未写的规则使用内置规则")
+	positional, err := parseArgs(fs, &common, args[1:])
+	if err != nil {
+		return err
+	}
+
+	mp, ok := amazon.LookupMarketplace(*marketplace)
+	if !ok {
+		return fmt.Errorf("%w: 未知的亚马逊站点 %q", errUsage, *marketplace)
+	}
+
+	spider := amazon.NewAmazonSpider().SetTimeout(common.timeout).SetMarketplace(mp)
+	if common.proxy != "" {
+		spider.SetProxy(common.proxy)
+	}
+	if common.proxyFile != "" {
+		spider.SetProxyPool(proxy.NewRotatingPool(proxy.FileSource(common.proxyFile)))
+	}
+	if common.retries >= 0 {
+		spider.SetRetryCount(common.retries)
+	}
+	if limiter := common.rateLimiter(); limiter != nil {
+		spider.SetRateLimiter(limiter)
+	}
+	if *rulesFile != "" {
+		rules, err := amazon.LoadRulesFile(*rulesFile)
+		if err != nil {
+			return err
+		}
+		spider.SetRules(rules)
+	}
+
+	req := platforms.Request{Params: map[string]string{amazon.ParamProxy: common.proxy, amazon.ParamMarketplace: mp.Code}}
+	switch args[0] {
+	case "product":
+		if len(positional) != 1 {
+			return fmt.Errorf("%w: 用法 spider-hub amazon product <url|ASIN>", errUsage)
+		}
+		req.Action = amazon.ActionProduct
+		req.Target = positional[0]
+	case "image-search":
+		if (*imageFile == "") == (*imageURL == "") {
+			return fmt.Errorf("%w: image-search 需要且只能指定 --file 或 --url 之一", errUsage)
+		}
+		req.Action = amazon.ActionImageSearch
+		req.Target = *imageURL
+		if *imageFile != "" {
+			data, err := os.ReadFile(*imageFile)
+			if err != nil {
+				return fmt.Errorf("读取图片失败: %w", err)
+			}
+			req.Body = data
+		}
+	default:
+		return fmt.Errorf("%w: 未知的amazon子命令 %q", errUsage, args[0])
+	}
+
+	result, err := amazon.NewAmazonPlatform(spider).Fetch(ctx, req)
+	if err != nil {
+		return err
+	}
+	return writeOutput(stdout, common.output, result.Data)
+}
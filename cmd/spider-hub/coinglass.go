@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xieburoucoco/spider-hub/platforms"
+	"github.com/xieburoucoco/spider-hub/platforms/coinglass"
+	"github.com/xieburoucoco/spider-hub/proxy"
+)
+
+// coinglassEndpoints 接口别名 -> 接口路径
+var coinglassEndpoints = map[string]string{
+	"coin-markets":        coinglass.CoinMarketsPath,
+	"open-interest-chart": coinglass.OpenInterestChartPath,
+	"futures-statistics":  coinglass.FuturesHomeStatisticsPath,
+	"exchanges":           coinglass.DerivativeExchangeListPath,
+	"pair-info":           coinglass.FuturesPairInfoPath,
+	"spot-coins":          coinglass.SpotSupportCoinsPath,
+}
+
+// coinglassAliasNames 返回排序后的接口别名
+func coinglassAliasNames() []string {
+	names := make([]string, 0, len(coinglassEndpoints))
+	for name := range coinglassEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runCoinglass 执行 coinglass 子命令
+//
+//	spider-hub coinglass get <endpoint> [key=value ...] [--base-url url]
+func runCoinglass(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] != coinglass.ActionGet {
+		return fmt.Errorf("%w: 用法 spider-hub coinglass get <endpoint> [key=value ...]", errUsage)
+	}
+
+	var common commonFlags
+	fs := newFlagSet("coinglass get", &common)
+	baseURL := fs.String("base-url", coinglass.DefaultBaseURL, "API地址")
+	positional, err := parseArgs(fs, &common, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("%w: 缺少 endpoint", errUsage)
+	}
+
+	endpoint := positional[0]
+	if path, ok := coinglassEndpoints[endpoint]; ok {
+		endpoint = path
+	}
+
+	params := make(map[string]string)
+	for _, kv := range positional[1:] {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("%w: 参数格式应为 key=value: %q", errUsage, kv)
+		}
+		params[key] = value
+	}
+
+	spider := coinglass.NewSpider().SetBaseURL(*baseURL).SetTimeout(common.timeout)
+	if common.proxy != "" {
+		spider.SetProxy(common.proxy)
+	}
+	if common.proxyFile != "" {
+		spider.SetProxyPool(proxy.NewRotatingPool(proxy.FileSource(common.proxyFile)))
+	}
+	if common.retries >= 0 {
+		spider.SetRetryCount(common.retries)
+	}
+	if limiter := common.rateLimiter(); limiter != nil {
+		spider.SetRateLimiter(limiter)
+	}
+
+	result, err := coinglass.NewPlatform(spider).Fetch(ctx, platforms.Request{
+		Action: coinglass.ActionGet,
+		Target: endpoint,
+		Params: params,
+	})
+	if err != nil {
+		return err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(result.Data.(json.RawMessage), &data); err != nil {
+		return fmt.Errorf("解析结果失败: %w", err)
+	}
+	return writeOutput(stdout, common.output, data)
+}
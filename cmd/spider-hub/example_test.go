@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/xieburoucoco/spider-hub/platforms/coinglass/coinglasstest"
+)
+
+// TestCoinglassGet 测试 coinglass get 子命令（离线模拟服务）
+func TestCoinglassGet(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"coinglass", "get", "spot-coins", "--base-url", server.URL}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("❌ 退出码 %d: %s", code, stderr.String())
+	}
+
+	var coins []string
+	if err := json.Unmarshal(stdout.Bytes(), &coins); err != nil || len(coins) == 0 {
+		t.Fatalf("❌ JSON输出解析失败: %v\n%s", err, stdout.String())
+	}
+}
+
+// TestCoinglassGetTable 测试表格输出
+func TestCoinglassGetTable(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"coinglass", "get", "coin-markets", "pageSize=5", "--output", "table", "--base-url", server.URL}
+	if code := run(context.Background(), args, &stdout, &stderr); code != exitOK {
+		t.Fatalf("❌ 退出码 %d: %s", code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "symbol") || !strings.Contains(lines[1], "BTC") {
+		t.Fatalf("❌ 表格输出不符:\n%s", stdout.String())
+	}
+	t.Logf("✅ 表格输出:\n%s", stdout.String())
+}
+
+// TestExitCodes 测试失败时的退出码
+func TestExitCodes(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+	server.SetStatus("/api/spot/support/coin", 503)
+
+	cases := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"未知命令", []string{"unknown"}, exitUsage},
+		{"缺少endpoint", []string{"coinglass", "get"}, exitUsage},
+		{"错误的输出格式", []string{"coinglass", "get", "spot-coins", "--output", "xml"}, exitUsage},
+		{"图片搜索缺少参数", []string{"amazon", "image-search"}, exitUsage},
+		{"未知的亚马逊站点", []string{"amazon", "image-search", "--url", "https://example.com/a.jpg", "--marketplace", "XX"}, exitUsage},
+		{"代理参数冲突", []string{"coinglass", "get", "spot-coins", "--proxy", "http://a:1", "--proxy-file", "proxies.txt"}, exitUsage},
+		{"限速参数无效", []string{"coinglass", "get", "spot-coins", "--rate", "-1"}, exitUsage},
+		{"代理文件不存在", []string{"coinglass", "get", "spot-coins", "--base-url", server.URL, "--proxy-file", "missing.txt"}, exitError},
+		{"服务端错误", []string{"coinglass", "get", "spot-coins", "--base-url", server.URL, "--retries", "0"}, exitError},
+		{"无效的商品URL", []string{"amazon", "product", "https://example.com"}, exitError},
+		{"规则文件不存在", []string{"amazon", "product", "B000000001", "--rules", "missing.json"}, exitError},
+	}
+
+	for _, c := range cases {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), c.args, &stdout, &stderr); code != c.code {
+			t.Errorf("❌ %s: 期望退出码 %d，实际 %d (%s)", c.name, c.code, code, stderr.String())
+		}
+	}
+}
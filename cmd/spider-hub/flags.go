@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xieburoucoco/spider-hub/ratelimit"
+)
+
+// 输出格式
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+)
+
+// commonFlags 所有子命令共用的参数
+type commonFlags struct {
+	proxy     string
+	proxyFile string
+	timeout   time.Duration
+	retries   int
+	rate      float64
+	output    string
+}
+
+// newFlagSet 创建子命令参数集并注册通用参数
+func newFlagSet(name string, common *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&common.proxy, "proxy", "", "代理地址")
+	fs.StringVar(&common.proxyFile, "proxy-file", "", "代理列表文件，每行一个代理，启用轮换代理池")
+	fs.DurationVar(&common.timeout, "timeout", 30*time.Second, "单次请求超时时间")
+	fs.IntVar(&common.retries, "retries", -1, "失败重试次数（-1表示使用默认值）")
+	fs.Float64Var(&common.rate, "rate", 0, "每个站点每秒最多请求数（0表示不限速）")
+	fs.StringVar(&common.output, "output", outputJSON, "输出格式 json|table")
+	return fs
+}
+
+// parseArgs 解析参数，允许参数与位置参数交错出现
+// 🔧 flag包遇到第一个位置参数即停止解析，这里逐段解析并收集位置参数
+func parseArgs(fs *flag.FlagSet, common *commonFlags, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if common.output != outputJSON && common.output != outputTable {
+		return nil, fmt.Errorf("%w: 不支持的输出格式 %q", errUsage, common.output)
+	}
+	if common.proxy != "" && common.proxyFile != "" {
+		return nil, fmt.Errorf("%w: --proxy 与 --proxy-file 不能同时使用", errUsage)
+	}
+	if common.timeout <= 0 {
+		return nil, fmt.Errorf("%w: --timeout 必须大于0", errUsage)
+	}
+	if common.rate < 0 {
+		return nil, fmt.Errorf("%w: --rate 不能小于0", errUsage)
+	}
+	return positional, nil
+}
+
+// rateLimiter 根据 --rate 创建限速器，未指定时返回nil
+// ⏳ 每个站点独立限速，收到 429/503 时按 Retry-After 暂停
+func (c *commonFlags) rateLimiter() *ratelimit.Limiter {
+	if c.rate <= 0 {
+		return nil
+	}
+	return ratelimit.New(c.rate, 1)
+}
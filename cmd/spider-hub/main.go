@@ -1,44 +1,121 @@
+// spider-hub 命令行工具
+//
+// 用法:
+//
+//	spider-hub platforms
+//	spider-hub coinglass get <endpoint> [key=value ...] [flags]
+//	spider-hub amazon product <url|ASIN> [--marketplace JP] [--rules rules.json] [flags]
+//	spider-hub amazon image-search --file <path> | --url <imageURL> [--marketplace JP] [flags]
+//
+// 通用参数:
+//
+//	--proxy       代理地址
+//	--proxy-file  代理列表文件（轮换代理池）
+//	--timeout     单次请求超时时间（如 30s）
+//	--retries     失败重试次数
+//	--rate        每个站点每秒最多请求数
+//	--output      输出格式 json | table
+//
+// 退出码: 0 成功，1 执行失败，2 参数错误
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/xieburoucoco/spider-hub/platforms"
+	_ "github.com/xieburoucoco/spider-hub/platforms/amazon"
+	_ "github.com/xieburoucoco/spider-hub/platforms/coinglass"
+)
+
+// 退出码
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
 )
 
+// errUsage 参数错误，对应退出码2
+var errUsage = errors.New("参数错误")
+
 func main() {
-	fmt.Println("🕷️ Spider-Hub")
-	fmt.Println("===============================================")
-	fmt.Println("一个专注于各平台爬虫逆向技术的Go语言项目集合")
-	fmt.Println("===============================================")
-	fmt.Println()
-
-	fmt.Println("📋 支持的平台:")
-	fmt.Println("• Coinglass - 加密货币数据平台（已破解AES加密）")
-	fmt.Println("• 更多平台持续添加中...")
-	fmt.Println()
-
-	fmt.Println("🚀 快速开始:")
-	fmt.Println("1. 查看平台文档:")
-	fmt.Println("   cd platforms/coinglass && cat README.md")
-	fmt.Println()
-	fmt.Println("2. 运行测试用例:")
-	fmt.Println("   cd platforms/coinglass && go test -v")
-	fmt.Println()
-	fmt.Println("3. 运行示例:")
-	fmt.Println("   cd platforms/coinglass && go test -run ExampleSpider_GetData -v")
-	fmt.Println()
-
-	fmt.Println("📚 项目特点:")
-	fmt.Println("• 🔓 破解复杂加密机制")
-	fmt.Println("• 🛡️ 绕过反爬虫检测")
-	fmt.Println("• 📊 获取实时数据")
-	fmt.Println("• 🔧 提供通用工具")
-	fmt.Println()
-
-	fmt.Println("⚠️  免责声明:")
-	fmt.Println("本项目仅供学习和研究使用，请遵守相关法律法规")
-	fmt.Println("和网站使用条款。使用者需要自行承担使用风险。")
-	fmt.Println()
-
-	fmt.Println("💼 商务合作: xieburoucoco@gmail.com")
-	fmt.Println("🔗 项目地址: https://github.com/xieburoucoco/spider-hub")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run 执行命令并返回退出码
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stdout)
+		return exitOK
+	}
+
+	var err error
+	switch args[0] {
+	case "platforms":
+		printPlatforms(stdout)
+	case "coinglass":
+		err = runCoinglass(ctx, args[1:], stdout)
+	case "amazon":
+		err = runAmazon(ctx, args[1:], stdout)
+	case "help", "-h", "--help":
+		printUsage(stdout)
+	default:
+		err = fmt.Errorf("%w: 未知命令 %q", errUsage, args[0])
+	}
+
+	if err == nil {
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "❌ %v\n", err)
+	if errors.Is(err, errUsage) {
+		fmt.Fprintln(stderr, "运行 spider-hub help 查看用法")
+		return exitUsage
+	}
+	return exitError
+}
+
+// printUsage 输出帮助信息
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "🕷️ Spider-Hub - 一个专注于各平台爬虫逆向技术的Go语言项目集合")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "用法:")
+	fmt.Fprintln(w, "  spider-hub platforms                                        列出已注册的平台")
+	fmt.Fprintln(w, "  spider-hub coinglass get <endpoint> [key=value ...]         获取Coinglass接口数据")
+	fmt.Fprintln(w, "  spider-hub amazon product <url|ASIN>                        获取亚马逊商品详情（ASIN配合 --marketplace 指定站点）")
+	fmt.Fprintln(w, "  spider-hub amazon image-search --file <path> | --url <url>  亚马逊以图搜商品（--marketplace 指定站点，如 JP）")
+	fmt.Fprintln(w, "  amazon product 可用 --rules <path> 指定商品详情页解析规则文件（JSON，覆盖内置规则）")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "通用参数:")
+	fmt.Fprintln(w, "  --proxy <url>         代理地址")
+	fmt.Fprintln(w, "  --proxy-file <path>   代理列表文件，每行一个代理，启用轮换代理池")
+	fmt.Fprintln(w, "  --timeout <duration>  单次请求超时时间 (默认 30s)")
+	fmt.Fprintln(w, "  --retries <n>         失败重试次数")
+	fmt.Fprintln(w, "  --rate <n>            每个站点每秒最多请求数，遇到429/503按Retry-After暂停 (默认不限速)")
+	fmt.Fprintln(w, "  --output json|table   输出格式 (默认 json)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Coinglass endpoint 可使用别名或接口路径:")
+	for _, alias := range coinglassAliasNames() {
+		fmt.Fprintf(w, "  %-22s %s\n", alias, coinglassEndpoints[alias])
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "⚠️  本项目仅供学习和研究使用，请遵守相关法律法规和网站使用条款。")
+}
+
+// printPlatforms 输出已注册的平台及其能力
+func printPlatforms(w io.Writer) {
+	fmt.Fprintln(w, "📋 支持的平台:")
+	for _, p := range platforms.List() {
+		fmt.Fprintf(w, "• %s\n", p.Name())
+		for _, c := range p.Capabilities() {
+			fmt.Fprintf(w, "    - %s: %s（目标: %s）\n", c.Action, c.Description, c.Target)
+		}
+	}
 }
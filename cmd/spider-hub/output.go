@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// maxCellWidth 表格单元格最大显示字符数
+const maxCellWidth = 60
+
+// writeOutput 按指定格式输出数据
+func writeOutput(w io.Writer, format string, data interface{}) error {
+	if format == outputTable {
+		return writeTable(w, data)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(data)
+}
+
+// writeTable 以表格形式输出数据
+//
+// 📊 输出规则:
+//   - 对象数组：每个对象一行，列为所有标量字段
+//   - 对象：若只有一个对象数组字段（如分页结果的list）则输出该数组，否则每个字段一行
+//   - 标量数组：每个元素一行
+func writeTable(w io.Writer, data interface{}) error {
+	// 🔄 统一转换为通用JSON结构
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("序列化结果失败: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("序列化结果失败: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	switch v := value.(type) {
+	case []interface{}:
+		writeRows(tw, v)
+	case map[string]interface{}:
+		if list, ok := singleObjectList(v); ok {
+			writeRows(tw, list)
+		} else {
+			writeKeyValues(tw, v)
+		}
+	default:
+		fmt.Fprintln(tw, formatCell(v))
+	}
+	return tw.Flush()
+}
+
+// singleObjectList 查找对象中唯一的对象数组字段
+func singleObjectList(obj map[string]interface{}) ([]interface{}, bool) {
+	var found []interface{}
+	count := 0
+	for _, field := range obj {
+		if list, ok := field.([]interface{}); ok && len(list) > 0 {
+			if _, isObj := list[0].(map[string]interface{}); isObj {
+				found = list
+				count++
+			}
+		}
+	}
+	return found, count == 1
+}
+
+// writeRows 输出数组
+func writeRows(w io.Writer, rows []interface{}) {
+	// 📋 收集所有对象的标量字段作为列
+	columnSet := make(map[string]bool)
+	for _, row := range rows {
+		if obj, ok := row.(map[string]interface{}); ok {
+			for key, field := range obj {
+				if isScalar(field) {
+					columnSet[key] = true
+				}
+			}
+		}
+	}
+
+	if len(columnSet) == 0 {
+		for _, row := range rows {
+			fmt.Fprintln(w, formatCell(row))
+		}
+		return
+	}
+
+	columns := make([]string, 0, len(columnSet))
+	for key := range columnSet {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		obj, _ := row.(map[string]interface{})
+		cells := make([]string, len(columns))
+		for i, key := range columns {
+			cells[i] = formatCell(obj[key])
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+}
+
+// writeKeyValues 输出对象的字段
+func writeKeyValues(w io.Writer, obj map[string]interface{}) {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\n", key, formatCell(obj[key]))
+	}
+}
+
+// isScalar 判断是否为标量值
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+// formatCell 格式化单元格内容，复合值输出为紧凑JSON并截断
+func formatCell(v interface{}) string {
+	var text string
+	switch value := v.(type) {
+	case nil:
+		text = ""
+	case string:
+		text = value
+	case float64:
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		encoded, _ := json.Marshal(value)
+		text = string(encoded)
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > maxCellWidth {
+		text = string(runes[:maxCellWidth-3]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,199 @@
+// Package errs 各平台共用的错误模型
+//
+// 🏷️ 所有平台的错误都归入以下类别之一，调用方（重试、告警等）可以按类别分支，
+// 无需匹配错误信息字符串:
+//
+//	var httpErr *errs.HTTPError
+//	switch {
+//	case errors.Is(err, errs.ErrBlocked):   // 🚫 验证码/封禁，更换代理
+//	case errors.As(err, &httpErr):          // 🌐 HTTP状态码错误
+//		log.Println(httpErr.StatusCode, httpErr.Body)
+//	case errors.Is(err, errs.ErrAPI):       // 📮 接口返回业务错误（success=false）
+//	case errors.Is(err, errs.ErrDecrypt):   // 🔓 解密失败
+//	case errors.Is(err, errs.ErrParse):     // 📄 响应格式变化
+//	}
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// 错误类别
+var (
+	ErrHTTP     = errors.New("HTTP请求失败")
+	ErrAPI      = errors.New("接口返回错误")
+	ErrDecrypt  = errors.New("数据解密失败")
+	ErrParse    = errors.New("数据解析失败")
+	ErrBlocked  = errors.New("请求被拦截")
+	ErrNotFound = errors.New("资源不存在")
+)
+
+// ErrKeyMismatch 密钥不匹配（AES解密后PKCS7填充无效）
+// ⏰ 出现在 StageUserHeader 阶段时通常是本地时钟偏差导致时间戳密钥错误，换新的时间戳重试可能成功
+var ErrKeyMismatch = errors.New("密钥不匹配")
+
+// MaxBodySize HTTPError 中保留的响应内容最大字节数
+const MaxBodySize = 512
+
+// NewKind 创建归属于某个类别的哨兵错误
+// 🧩 平台用它定义更细的错误，如 amazon.ErrCaptcha 归属于 ErrBlocked，
+// errors.Is(err, amazon.ErrCaptcha) 和 errors.Is(err, errs.ErrBlocked) 均成立
+func NewKind(category error, msg string) error {
+	return &kindError{msg: msg, category: category}
+}
+
+// kindError 归属于某个类别的哨兵错误
+type kindError struct {
+	msg      string
+	category error
+}
+
+func (e *kindError) Error() string { return e.msg }
+func (e *kindError) Unwrap() error { return e.category }
+
+// HTTPError HTTP状态码错误
+// 🌐 属于 ErrHTTP；404 同时属于 ErrNotFound，403/429 同时属于 ErrBlocked
+type HTTPError struct {
+	Platform   string // 平台名称
+	URL        string // 请求地址
+	StatusCode int    // HTTP状态码
+	Body       string // 响应内容，最多保留 MaxBodySize 字节
+}
+
+// NewHTTPError 创建HTTP状态码错误，响应内容超过 MaxBodySize 时截断
+func NewHTTPError(platform, url string, statusCode int, body []byte) *HTTPError {
+	if len(body) > MaxBodySize {
+		body = body[:MaxBodySize]
+	}
+	return &HTTPError{
+		Platform:   platform,
+		URL:        url,
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+}
+
+// Error 实现error接口
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s HTTP请求失败，状态码: %d: %s", e.Platform, e.StatusCode, e.URL)
+}
+
+// Is 按状态码归类
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrHTTP:
+		return true
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrBlocked:
+		return e.StatusCode == http.StatusForbidden || e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// Temporary 是否为可重试的临时错误（429或5xx）
+func (e *HTTPError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// APIError 接口业务错误（HTTP 200，但响应体标明请求失败，如 {"code":"404","success":false}）
+// 📮 属于 ErrAPI；Code 为 "404" 时同时属于 ErrNotFound
+type APIError struct {
+	Platform string // 平台名称
+	URL      string // 请求地址
+	Code     string // 接口返回的错误码
+	Msg      string // 接口返回的错误信息
+}
+
+// Error 实现error接口
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s接口返回错误，错误码: %s (%s): %s", e.Platform, e.Code, e.Msg, e.URL)
+}
+
+// Is 属于 ErrAPI，错误码 404 同时属于 ErrNotFound
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrAPI:
+		return true
+	case ErrNotFound:
+		return e.Code == "404"
+	}
+	return false
+}
+
+// DecryptStage 解密阶段
+type DecryptStage string
+
+const (
+	StageUserHeader DecryptStage = "user header" // 🔑 解码/解密 user 响应头
+	StageDynamicKey DecryptStage = "dynamic key" // 🗝️ 解析动态密钥
+	StagePayload    DecryptStage = "payload"     // 📦 解码/解密响应数据
+	StageGzip       DecryptStage = "gzip"        // 🗜️ 解压最终数据
+)
+
+// DecryptError 解密错误
+// 🔓 属于 ErrDecrypt，Stage 标明出错的解密阶段
+type DecryptError struct {
+	Platform string       // 平台名称
+	Stage    DecryptStage // 出错阶段
+	Err      error        // 底层错误
+}
+
+// Error 实现error接口
+func (e *DecryptError) Error() string {
+	return fmt.Sprintf("%s数据解密失败: %s: %v", e.Platform, e.Stage, e.Err)
+}
+
+// Is 属于 ErrDecrypt
+func (e *DecryptError) Is(target error) bool { return target == ErrDecrypt }
+
+// Unwrap 返回底层错误
+func (e *DecryptError) Unwrap() error { return e.Err }
+
+// Temporary 是否为可通过重试恢复的错误
+// ⏰ 仅 user 响应头存在但密钥不匹配（ErrKeyMismatch）时为true，缺少响应头、编码错误等重试也不会成功
+func (e *DecryptError) Temporary() bool {
+	return e.Stage == StageUserHeader && errors.Is(e.Err, ErrKeyMismatch)
+}
+
+// ParseError 解析错误（JSON/HTML格式与预期不符）
+// 📄 属于 ErrParse
+type ParseError struct {
+	Platform string // 平台名称
+	What     string // 解析对象，如 "响应"、"stylesnap令牌"
+	Err      error  // 底层错误
+}
+
+// Error 实现error接口
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s解析%s失败: %v", e.Platform, e.What, e.Err)
+}
+
+// Is 属于 ErrParse
+func (e *ParseError) Is(target error) bool { return target == ErrParse }
+
+// Unwrap 返回底层错误
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// PageError 异常页面错误（验证码、登录墙、页面不存在等）
+// 🚧 Kind 为平台定义的哨兵错误，其类别（如 ErrBlocked）决定该错误的类别
+type PageError struct {
+	Platform   string // 平台名称
+	Kind       error  // 错误类型
+	URL        string // 请求地址
+	FinalURL   string // 重定向后的最终地址
+	StatusCode int    // HTTP状态码
+}
+
+// Error 实现error接口
+func (e *PageError) Error() string {
+	if e.FinalURL != "" && e.FinalURL != e.URL {
+		return fmt.Sprintf("%v: %s -> %s (状态码 %d)", e.Kind, e.URL, e.FinalURL, e.StatusCode)
+	}
+	return fmt.Sprintf("%v: %s (状态码 %d)", e.Kind, e.URL, e.StatusCode)
+}
+
+// Unwrap 返回错误类型
+func (e *PageError) Unwrap() error { return e.Kind }
@@ -0,0 +1,85 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestHTTPError 测试HTTP错误按状态码归类
+func TestHTTPError(t *testing.T) {
+	cases := []struct {
+		status    int
+		notFound  bool
+		blocked   bool
+		temporary bool
+	}{
+		{400, false, false, false},
+		{403, false, true, false},
+		{404, true, false, false},
+		{429, false, true, true},
+		{502, false, false, true},
+	}
+	for _, c := range cases {
+		var err error = NewHTTPError("test", "https://example.com", c.status, nil)
+		if !errors.Is(err, ErrHTTP) || errors.Is(err, ErrNotFound) != c.notFound || errors.Is(err, ErrBlocked) != c.blocked {
+			t.Errorf("❌ 状态码 %d 归类错误", c.status)
+		}
+		var httpErr *HTTPError
+		if !errors.As(fmt.Errorf("包装: %w", err), &httpErr) || httpErr.Temporary() != c.temporary {
+			t.Errorf("❌ 状态码 %d 的 Temporary 判断错误", c.status)
+		}
+	}
+
+	long := NewHTTPError("test", "https://example.com", 500, []byte(strings.Repeat("x", MaxBodySize+100)))
+	if len(long.Body) != MaxBodySize {
+		t.Fatalf("❌ 响应内容应截断为 %d 字节，实际 %d", MaxBodySize, len(long.Body))
+	}
+}
+
+// TestErrorCategories 测试各错误类型的类别与底层错误
+func TestErrorCategories(t *testing.T) {
+	cause := errors.New("底层错误")
+	errCaptcha := NewKind(ErrBlocked, "验证码")
+
+	cases := []struct {
+		name     string
+		err      error
+		category error
+	}{
+		{"解密", &DecryptError{Platform: "test", Stage: StageGzip, Err: cause}, ErrDecrypt},
+		{"解析", &ParseError{Platform: "test", What: "响应", Err: cause}, ErrParse},
+		{"接口", &APIError{Platform: "test", Code: "500", Msg: "server error"}, ErrAPI},
+		{"页面", &PageError{Platform: "test", Kind: errCaptcha, URL: "https://example.com"}, ErrBlocked},
+	}
+	for _, c := range cases {
+		wrapped := fmt.Errorf("包装: %w", c.err)
+		if !errors.Is(wrapped, c.category) {
+			t.Errorf("❌ %s错误应属于 %v", c.name, c.category)
+		}
+		for _, other := range []error{ErrHTTP, ErrAPI, ErrDecrypt, ErrParse, ErrBlocked, ErrNotFound} {
+			if other != c.category && errors.Is(wrapped, other) {
+				t.Errorf("❌ %s错误不应属于 %v", c.name, other)
+			}
+		}
+	}
+
+	if !errors.Is(cases[0].err, cause) || !errors.Is(cases[1].err, cause) || !errors.Is(cases[3].err, errCaptcha) {
+		t.Fatal("❌ 应保留底层错误")
+	}
+	if notFound := (&APIError{Platform: "test", Code: "404"}); !errors.Is(notFound, ErrAPI) || !errors.Is(notFound, ErrNotFound) {
+		t.Fatal("❌ 错误码404的接口错误应同时属于 ErrNotFound")
+	}
+}
+
+// ExampleDecryptError 按解密阶段分支处理
+func ExampleDecryptError() {
+	var err error = &DecryptError{Platform: "coinglass", Stage: StageUserHeader, Err: ErrKeyMismatch}
+
+	var decryptErr *DecryptError
+	if errors.As(err, &decryptErr) && decryptErr.Temporary() {
+		fmt.Println("可能是本地时钟偏差:", err)
+	}
+	// Output: 可能是本地时钟偏差: coinglass数据解密失败: user header: 密钥不匹配
+}
@@ -0,0 +1,120 @@
+package fingerprint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestProfilesCoherent 测试预置指纹各字段互相一致
+func TestProfilesCoherent(t *testing.T) {
+	names := map[string]bool{}
+	for _, p := range Profiles() {
+		if names[p.Name] {
+			t.Errorf("❌ 指纹名称重复: %s", p.Name)
+		}
+		names[p.Name] = true
+
+		if !strings.Contains(p.UserAgent, p.Version) {
+			t.Errorf("❌ %s: user-agent 与版本 %s 不一致", p.Name, p.Version)
+		}
+		chromium := p.Browser == Chrome || p.Browser == Edge
+		if p.Chromium() != chromium {
+			t.Errorf("❌ %s: 只有Chromium浏览器发送 sec-ch-ua", p.Name)
+		}
+		if chromium && !strings.Contains(p.SecCHUA, `"Chromium";v="`+p.Version+`"`) {
+			t.Errorf("❌ %s: sec-ch-ua 与版本不一致: %s", p.Name, p.SecCHUA)
+		}
+		platformMarkers := map[string]string{"Windows": "Windows NT", "macOS": "Macintosh", "Linux": "Linux"}
+		if !strings.Contains(p.UserAgent, platformMarkers[p.Platform]) {
+			t.Errorf("❌ %s: user-agent 与操作系统 %s 不一致", p.Name, p.Platform)
+		}
+		if p.AcceptLanguage == "" {
+			t.Errorf("❌ %s: 缺少 accept-language", p.Name)
+		}
+		if found, ok := ProfileByName(p.Name); !ok || found.UserAgent != p.UserAgent {
+			t.Errorf("❌ ProfileByName(%q) 查找失败", p.Name)
+		}
+	}
+	if len(ProfilesByBrowser(Firefox)) == 0 || len(ProfilesByBrowser(Safari)) == 0 {
+		t.Fatal("❌ 缺少 Firefox/Safari 指纹")
+	}
+}
+
+// TestApply 测试指纹与平台请求头的合并
+func TestApply(t *testing.T) {
+	base := map[string]string{
+		"Accept":             "application/json",
+		"accept-language":    "zh-CN,zh;q=0.9",
+		"sec-ch-ua":          `"Brave";v="124"`,
+		"sec-ch-ua-platform": `"Windows"`,
+		"user-agent":         "old",
+	}
+
+	chrome := Chrome138Mac.Apply(base)
+	if chrome["accept"] != "application/json" || chrome["accept-language"] != "zh-CN,zh;q=0.9" {
+		t.Fatalf("❌ 应保留平台请求头: %v", chrome)
+	}
+	if chrome["user-agent"] != Chrome138Mac.UserAgent || chrome["sec-ch-ua"] != Chrome138Mac.SecCHUA || chrome["sec-ch-ua-platform"] != `"macOS"` {
+		t.Fatalf("❌ 浏览器身份应取自指纹: %v", chrome)
+	}
+
+	firefox := Firefox140Windows.Apply(base)
+	for key := range firefox {
+		if strings.HasPrefix(key, "sec-ch-ua") {
+			t.Fatalf("❌ Firefox 不应发送 %s", key)
+		}
+	}
+	if firefox["user-agent"] != Firefox140Windows.UserAgent {
+		t.Fatalf("❌ user-agent 错误: %s", firefox["user-agent"])
+	}
+	if got := Safari18Mac.Apply(nil)["accept-language"]; got != Safari18Mac.AcceptLanguage {
+		t.Fatalf("❌ 平台未设置时应使用指纹的 accept-language: %s", got)
+	}
+	if base["user-agent"] != "old" {
+		t.Fatal("❌ Apply 不应修改传入的请求头")
+	}
+}
+
+// TestSelector 测试按会话固定和按请求随机选择
+func TestSelector(t *testing.T) {
+	selector := NewSelector()
+	for _, session := range []string{"", "account-1", "account-2"} {
+		first := selector.Pick(session)
+		for i := 0; i < 10; i++ {
+			if selector.Pick(session).Name != first.Name {
+				t.Fatalf("❌ 会话 %q 应始终使用同一个指纹", session)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		seen[selector.Pick(fmt.Sprintf("session-%d", i)).Name] = true
+	}
+	if len(seen) < len(Profiles())/2 {
+		t.Fatalf("❌ 不同会话应分散到多个指纹: %v", seen)
+	}
+
+	selector = NewSelector(Firefox140Windows, Safari18Mac).SetMode(PerRequest)
+	seen = map[string]bool{}
+	for i := 0; i < 100; i++ {
+		seen[selector.Pick("account-1").Name] = true
+	}
+	if len(seen) != 2 {
+		t.Fatalf("❌ 按请求选择时应随机使用所有指纹: %v", seen)
+	}
+}
+
+// ExampleProfile_Apply 由指纹生成请求头
+func ExampleProfile_Apply() {
+	headers := Firefox140Windows.Apply(map[string]string{
+		"accept":    "application/json",
+		"sec-ch-ua": `"Chromium";v="124"`,
+	})
+	fmt.Println(headers["user-agent"])
+	fmt.Println(headers["accept"], headers["sec-ch-ua"] == "")
+	// Output:
+	// Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:140.0) Gecko/20100101 Firefox/140.0
+	// application/json true
+}
@@ -0,0 +1,156 @@
+// Package fingerprint 浏览器指纹配置
+//
+// 🎭 每个 Profile 描述一个真实浏览器版本的请求头特征（user-agent、sec-ch-ua 系列、
+// 操作系统、默认语言），各平台的请求头由指纹生成而不是写死，
+// 浏览器更新时只需更新这里的配置。
+//
+// 使用示例:
+//
+//	// 🎲 每个会话随机选择一个指纹，同一会话始终使用同一个
+//	selector := fingerprint.NewSelector(fingerprint.Profiles()...)
+//	spider := amazon.NewAmazonSpider().SetFingerprints(selector)
+//
+//	headers := fingerprint.Firefox140Windows.Apply(map[string]string{"accept": "*/*"})
+package fingerprint
+
+import "strings"
+
+// Browser 浏览器类型
+type Browser string
+
+const (
+	Chrome  Browser = "chrome"
+	Edge    Browser = "edge"
+	Firefox Browser = "firefox"
+	Safari  Browser = "safari"
+)
+
+// Profile 浏览器指纹
+//
+// 只有基于Chromium的浏览器（Chrome、Edge）发送 sec-ch-ua 系列请求头，
+// Firefox 和 Safari 的 SecCHUA 为空
+type Profile struct {
+	Name           string  `json:"name"`            // 指纹名称，如 "chrome-138-windows"
+	Browser        Browser `json:"browser"`         // 浏览器类型
+	Version        string  `json:"version"`         // 浏览器主版本号
+	Platform       string  `json:"platform"`        // 操作系统，如 "Windows"、"macOS"
+	UserAgent      string  `json:"user_agent"`      // user-agent
+	SecCHUA        string  `json:"sec_ch_ua"`       // sec-ch-ua，非Chromium浏览器为空
+	AcceptLanguage string  `json:"accept_language"` // 默认 accept-language
+}
+
+// 预置指纹
+var (
+	Chrome138Windows = Profile{
+		Name: "chrome-138-windows", Browser: Chrome, Version: "138", Platform: "Windows",
+		UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/138.0.0.0 Safari/537.36",
+		SecCHUA:        `"Not)A;Brand";v="8", "Chromium";v="138", "Google Chrome";v="138"`,
+		AcceptLanguage: "en-US,en;q=0.9",
+	}
+	Chrome138Mac = Profile{
+		Name: "chrome-138-macos", Browser: Chrome, Version: "138", Platform: "macOS",
+		UserAgent:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/138.0.0.0 Safari/537.36",
+		SecCHUA:        `"Not)A;Brand";v="8", "Chromium";v="138", "Google Chrome";v="138"`,
+		AcceptLanguage: "en-US,en;q=0.9",
+	}
+	Chrome138Linux = Profile{
+		Name: "chrome-138-linux", Browser: Chrome, Version: "138", Platform: "Linux",
+		UserAgent:      "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/138.0.0.0 Safari/537.36",
+		SecCHUA:        `"Not)A;Brand";v="8", "Chromium";v="138", "Google Chrome";v="138"`,
+		AcceptLanguage: "en-US,en;q=0.9",
+	}
+	Edge138Windows = Profile{
+		Name: "edge-138-windows", Browser: Edge, Version: "138", Platform: "Windows",
+		UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/138.0.0.0 Safari/537.36 Edg/138.0.0.0",
+		SecCHUA:        `"Not)A;Brand";v="8", "Chromium";v="138", "Microsoft Edge";v="138"`,
+		AcceptLanguage: "en-US,en;q=0.9",
+	}
+	Firefox140Windows = Profile{
+		Name: "firefox-140-windows", Browser: Firefox, Version: "140", Platform: "Windows",
+		UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:140.0) Gecko/20100101 Firefox/140.0",
+		AcceptLanguage: "en-US,en;q=0.5",
+	}
+	Firefox140Mac = Profile{
+		Name: "firefox-140-macos", Browser: Firefox, Version: "140", Platform: "macOS",
+		UserAgent:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:140.0) Gecko/20100101 Firefox/140.0",
+		AcceptLanguage: "en-US,en;q=0.5",
+	}
+	Safari18Mac = Profile{
+		Name: "safari-18-macos", Browser: Safari, Version: "18.5", Platform: "macOS",
+		UserAgent:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.5 Safari/605.1.15",
+		AcceptLanguage: "en-US,en;q=0.9",
+	}
+)
+
+// DefaultProfile 未配置指纹选择器时使用的指纹
+var DefaultProfile = Chrome138Windows
+
+// Profiles 返回所有预置指纹
+func Profiles() []Profile {
+	return []Profile{
+		Chrome138Windows, Chrome138Mac, Chrome138Linux, Edge138Windows,
+		Firefox140Windows, Firefox140Mac, Safari18Mac,
+	}
+}
+
+// ProfileByName 按名称查找预置指纹
+func ProfileByName(name string) (Profile, bool) {
+	for _, p := range Profiles() {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// ProfilesByBrowser 返回指定浏览器的所有预置指纹
+func ProfilesByBrowser(browser Browser) []Profile {
+	var list []Profile
+	for _, p := range Profiles() {
+		if p.Browser == browser {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// Chromium 是否为基于Chromium的浏览器（发送 sec-ch-ua 系列请求头）
+func (p Profile) Chromium() bool {
+	return p.SecCHUA != ""
+}
+
+// Headers 返回指纹自身的请求头：user-agent、accept-language，Chromium浏览器另有 sec-ch-ua 系列
+func (p Profile) Headers() map[string]string {
+	headers := map[string]string{
+		"user-agent":      p.UserAgent,
+		"accept-language": p.AcceptLanguage,
+	}
+	if p.Chromium() {
+		headers["sec-ch-ua"] = p.SecCHUA
+		headers["sec-ch-ua-mobile"] = "?0"
+		headers["sec-ch-ua-platform"] = `"` + p.Platform + `"`
+	}
+	return headers
+}
+
+// Apply 将指纹合并到平台的请求头中，返回新的请求头
+//
+// 🎭 浏览器身份相关的请求头（user-agent、sec-ch-ua*）总是取自指纹，headers 中的同名请求头会被丢弃，
+// 保证非Chromium指纹不会带上 sec-ch-ua；accept-language 仅在 headers 中没有时使用指纹的默认值
+func (p Profile) Apply(headers map[string]string) map[string]string {
+	result := make(map[string]string, len(headers)+5)
+	for key, value := range headers {
+		lower := strings.ToLower(key)
+		if lower == "user-agent" || strings.HasPrefix(lower, "sec-ch-ua") {
+			continue
+		}
+		result[lower] = value
+	}
+	for key, value := range p.Headers() {
+		if _, ok := result[key]; ok && key == "accept-language" {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package fingerprint
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// Mode 指纹选择方式
+type Mode int
+
+const (
+	PerSession Mode = iota // 📌 同一会话始终使用同一个指纹（默认），不同会话随机分配
+	PerRequest             // 🎲 每次请求随机选择
+)
+
+// Selector 指纹选择器，可在多个goroutine中并发使用
+//
+// 会话通常来自 proxy.WithSession，未设置会话的请求视为同一个会话
+type Selector struct {
+	profiles []Profile
+	mode     Mode
+	seed     uint64 // 每个选择器不同，使同名会话在不同选择器中分配到不同指纹
+}
+
+// NewSelector 创建指纹选择器，未指定指纹时使用所有预置指纹
+func NewSelector(profiles ...Profile) *Selector {
+	if len(profiles) == 0 {
+		profiles = Profiles()
+	}
+	return &Selector{
+		profiles: append([]Profile(nil), profiles...),
+		seed:     rand.Uint64(),
+	}
+}
+
+// SetMode 设置选择方式（默认 PerSession）
+func (s *Selector) SetMode(mode Mode) *Selector {
+	s.mode = mode
+	return s
+}
+
+// Profiles 返回选择器中的指纹
+func (s *Selector) Profiles() []Profile {
+	return append([]Profile(nil), s.profiles...)
+}
+
+// Pick 为会话选择指纹
+// 📌 PerSession 模式按会话名称哈希选择，无需保存状态，同一会话总是得到同一个指纹
+func (s *Selector) Pick(session string) Profile {
+	if s.mode == PerRequest {
+		return s.profiles[rand.N(len(s.profiles))]
+	}
+	h := fnv.New64a()
+	h.Write([]byte(session))
+	return s.profiles[(h.Sum64()^s.seed)%uint64(len(s.profiles))]
+}
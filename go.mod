@@ -2,10 +2,12 @@ module github.com/xieburoucoco/spider-hub
 
 go 1.24
 
-require github.com/go-resty/resty/v2 v2.16.5
+require (
+	github.com/PuerkitoBio/goquery v1.10.3
+	github.com/go-resty/resty/v2 v2.16.5
+)
 
 require (
-	github.com/PuerkitoBio/goquery v1.10.3 // indirect
 	github.com/andybalholm/cascadia v1.3.3 // indirect
 	golang.org/x/net v0.39.0 // indirect
 )
@@ -0,0 +1,46 @@
+// Package util 各平台共用的内部工具
+package util
+
+import (
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ClientCache 按代理地址缓存resty客户端
+// 🔒 resty.Client 的代理是客户端级别的配置，为每个代理地址创建独立客户端，
+// 避免在并发请求之间修改共享客户端
+type ClientCache struct {
+	newClient func(proxyURL string) *resty.Client
+
+	mu      sync.Mutex
+	clients map[string]*resty.Client
+}
+
+// NewClientCache 创建客户端缓存，newClient 按爬虫当前配置创建使用指定代理的客户端
+func NewClientCache(newClient func(proxyURL string) *resty.Client) *ClientCache {
+	return &ClientCache{newClient: newClient}
+}
+
+// Get 返回使用指定代理的客户端，首次使用时创建并缓存
+func (c *ClientCache) Get(proxyURL string) *resty.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[proxyURL]; ok {
+		return client
+	}
+	if c.clients == nil {
+		c.clients = make(map[string]*resty.Client)
+	}
+	client := c.newClient(proxyURL)
+	c.clients[proxyURL] = client
+	return client
+}
+
+// Reset 清空缓存，爬虫配置变更后调用，下次使用时按新配置重建
+func (c *ClientCache) Reset() {
+	c.mu.Lock()
+	c.clients = nil
+	c.mu.Unlock()
+}
@@ -0,0 +1,37 @@
+package util
+
+import (
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/xieburoucoco/spider-hub/ratelimit"
+)
+
+// UseRateLimiter 为客户端安装限速钩子
+// ⏳ 每次请求（包括resty重试）前按站点/代理等待令牌，收到 429/503 时按 Retry-After 暂停；
+// limiter 在每次请求时调用，返回nil表示不限速；proxyURL 返回客户端使用的代理地址
+func UseRateLimiter(client *resty.Client, limiter func() *ratelimit.Limiter, proxyURL func() string) *resty.Client {
+	client.OnBeforeRequest(func(c *resty.Client, r *resty.Request) error {
+		l := limiter()
+		if l == nil {
+			return nil
+		}
+		return l.Wait(r.Context(), hostname(r.URL), proxyURL())
+	})
+	client.OnAfterResponse(func(c *resty.Client, resp *resty.Response) error {
+		if l := limiter(); l != nil {
+			l.Observe(hostname(resp.Request.URL), proxyURL(), resp.StatusCode(), resp.Header())
+		}
+		return nil
+	})
+	return client
+}
+
+// hostname 返回请求地址的主机名，无法解析时返回空字符串
+func hostname(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
@@ -4,83 +4,353 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/go-resty/resty/v2"
+	"github.com/xieburoucoco/spider-hub/errs"
+	"github.com/xieburoucoco/spider-hub/fingerprint"
+	"github.com/xieburoucoco/spider-hub/internal/util"
+	"github.com/xieburoucoco/spider-hub/proxy"
+	"github.com/xieburoucoco/spider-hub/ratelimit"
+	"github.com/xieburoucoco/spider-hub/retry"
 )
 
 // 🕷️ AmazonSpider 亚马逊爬虫结构体
-// 负责爬取亚马逊商品详情页面并解析数据
+// 负责爬取亚马逊商品详情页面并解析数据，可在多个goroutine中并发使用
 type AmazonSpider struct {
 	client    *resty.Client    // HTTP客户端
 	extractor *AmazonExtractor // 数据提取器
 	util      *AmazonUtil      // 工具函数
+
+	timeout     time.Duration // 单次请求超时
+	retryPolicy retry.Policy  // 重试策略
+	marketplace Marketplace   // 默认站点（搜索、评论、图片搜索）
+
+	proxyURL     string                // 共享客户端使用的代理地址
+	proxyClients *util.ClientCache     // 按代理地址缓存的客户端，避免修改共享客户端
+	proxyPool    proxy.Pool            // 代理池（可选）
+	limiter      *ratelimit.Limiter    // 限速器（可选）
+	fingerprints *fingerprint.Selector // 浏览器指纹选择器（可选）
 }
 
 // 🏭 NewAmazonSpider 创建新的亚马逊爬虫实例
 //
 // 返回一个配置好的亚马逊爬虫，包含:
-// - 配置了超时和重试的resty客户端
+// - 配置了超时的resty客户端
+// - 默认重试策略（最多尝试 MaxRetries 次）
 // - 数据提取器
 // - 工具函数集
 func NewAmazonSpider() *AmazonSpider {
-	// 创建resty客户端
-	client := resty.New().
-		SetTimeout(time.Duration(RequestTimeout) * time.Second).
-		SetRetryCount(3).
-		SetRetryWaitTime(5 * time.Second).
-		SetRetryMaxWaitTime(20 * time.Second)
-
-	// 设置默认请求头
-	for key, value := range AmazonHeaders {
+	s := &AmazonSpider{
+		extractor:   NewAmazonExtractor(),
+		util:        &AmazonUtil{},
+		timeout:     time.Duration(RequestTimeout) * time.Second,
+		retryPolicy: retry.Default(),
+		marketplace: MarketplaceUS,
+	}
+	s.retryPolicy.MaxAttempts = MaxRetries
+	s.client = s.newClient("")
+	s.proxyClients = util.NewClientCache(s.newClient)
+	return s
+}
+
+// 🔧 newClient 按当前配置创建使用指定代理的resty客户端，proxyURL为空时不设置代理
+func (s *AmazonSpider) newClient(proxyURL string) *resty.Client {
+	// 🔁 重试由 retryPolicy 在请求之上统一处理，客户端本身不重试
+	client := resty.New().SetTimeout(s.timeout)
+
+	// 设置与浏览器无关的默认请求头，浏览器指纹相关的请求头在每次请求时设置
+	for key, value := range amazonRequestHeaders {
 		client.SetHeader(key, value)
 	}
+	if proxyURL != "" {
+		client.SetProxy(proxyURL)
+	}
 
-	return &AmazonSpider{
-		client:    client,
-		extractor: NewAmazonExtractor(),
-		util:      &AmazonUtil{},
+	// ⏳ 限速按实际使用的代理分桶，共享客户端使用 SetProxy 设置的代理
+	return util.UseRateLimiter(client, func() *ratelimit.Limiter { return s.limiter }, func() string {
+		if proxyURL != "" {
+			return proxyURL
+		}
+		return s.proxyURL
+	})
+}
+
+// 🔧 clientFor 返回使用指定代理的客户端
+//
+// 代理为空时返回共享客户端；否则返回该代理专用的客户端（首次使用时创建并缓存），
+// 单次调用的代理不会影响其他请求
+func (s *AmazonSpider) clientFor(proxyURL string) *resty.Client {
+	if proxyURL == "" {
+		return s.client
 	}
+	return s.proxyClients.Get(proxyURL)
 }
 
+// 🔧 execute 为单次请求选择客户端并发送
+//
+// 显式指定的代理优先；否则从代理池（如已配置）选取代理，
+// 并根据响应结果向代理池上报代理的健康状态
+func (s *AmazonSpider) execute(ctx context.Context, proxyURL string, send func(req *resty.Request) (*resty.Response, error)) (*resty.Response, error) {
+	pool := s.proxyPool
+	if proxyURL != "" || pool == nil {
+		return send(s.newRequest(ctx, s.clientFor(proxyURL)))
+	}
+
+	proxyURL, err := pool.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取代理失败: %w", err)
+	}
+	resp, err := send(s.newRequest(ctx, s.clientFor(proxyURL)))
+
+	// 调用方取消的请求不计入代理健康状态
+	if ctx.Err() == nil {
+		pool.Report(proxyURL, proxyOutcome(resp, err))
+	}
+	return resp, err
+}
+
+// 🔧 newRequest 创建请求并设置浏览器指纹相关的请求头（user-agent、sec-ch-ua、accept-language）
+func (s *AmazonSpider) newRequest(ctx context.Context, client *resty.Client) *resty.Request {
+	return client.R().SetContext(ctx).SetHeaders(s.profile(ctx).Headers())
+}
+
+// 🎭 profile 返回本次请求使用的浏览器指纹，同一代理会话使用同一个指纹
+func (s *AmazonSpider) profile(ctx context.Context) fingerprint.Profile {
+	if s.fingerprints == nil {
+		return fingerprint.DefaultProfile
+	}
+	return s.fingerprints.Pick(proxy.SessionFromContext(ctx))
+}
+
+// 🔧 proxyOutcome 判断请求结果，验证码、狗狗页、登录墙和地区重定向视为代理被封禁
+func proxyOutcome(resp *resty.Response, err error) proxy.Outcome {
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode()
+	}
+	outcome := proxy.OutcomeFor(statusCode, err)
+	if outcome == proxy.Success && IsBlocked(checkPage(resp)) {
+		return proxy.Blocked
+	}
+	return outcome
+}
+
+// ⏱️ SetTimeout 设置单次HTTP请求的超时时间
+//
+// 与其他 Set 方法一样，应在发起请求前调用
+func (s *AmazonSpider) SetTimeout(timeout time.Duration) *AmazonSpider {
+	s.timeout = timeout
+	s.client.SetTimeout(timeout)
+	s.proxyClients.Reset()
+	return s
+}
+
+// 🌐 SetProxy 设置所有请求默认使用的代理地址
+//
+// 图片搜索接口的proxies参数优先于此处的默认代理；设置了代理池时以代理池为准
+func (s *AmazonSpider) SetProxy(proxyURL string) *AmazonSpider {
+	s.proxyURL = proxyURL
+	s.client.SetProxy(proxyURL)
+	return s
+}
+
+// 🔄 SetProxyPool 设置代理池
+//
+// 每次请求从代理池中选取代理，并根据状态码和验证码页面上报代理健康状态；
+// 图片搜索接口的proxies参数仍优先于代理池
+func (s *AmazonSpider) SetProxyPool(pool proxy.Pool) *AmazonSpider {
+	s.proxyPool = pool
+	return s
+}
+
+// ⏳ SetRateLimiter 设置限速器
+//
+// 每次请求前按限速器的范围（站点/代理）等待，收到 429/503 时按 Retry-After 暂停；
+// 同一个限速器可以在多个爬虫之间共享
+func (s *AmazonSpider) SetRateLimiter(limiter *ratelimit.Limiter) *AmazonSpider {
+	s.limiter = limiter
+	return s
+}
+
+// 🎭 SetFingerprints 设置浏览器指纹选择器（默认固定使用 fingerprint.DefaultProfile）
+//
+// 每次请求的 user-agent、sec-ch-ua 系列请求头由选择的指纹生成；accept-language 仍按站点设置。
+// 按会话选择时，同一 proxy.WithSession 会话（包括图片搜索自动绑定的会话）始终使用同一个指纹
+func (s *AmazonSpider) SetFingerprints(selector *fingerprint.Selector) *AmazonSpider {
+	s.fingerprints = selector
+	return s
+}
+
+// 📐 SetRules 设置商品详情页解析规则（默认使用内置规则），nil 表示恢复内置规则
+//
+// 规则可在抓取过程中随时替换，例如页面改版后重新加载规则文件:
+//
+//	rules, err := amazon.LoadRulesFile("rules.json")
+//	if err == nil {
+//		spider.SetRules(rules)
+//	}
+func (s *AmazonSpider) SetRules(rules *Rules) *AmazonSpider {
+	s.extractor.SetRules(rules)
+	return s
+}
+
+// 🌍 SetMarketplace 设置搜索、评论和图片搜索默认使用的站点（默认美国站）
+//
+// 商品详情按商品URL的域名自动识别站点，不受此设置影响
+func (s *AmazonSpider) SetMarketplace(m Marketplace) *AmazonSpider {
+	s.marketplace = m
+	return s
+}
+
+// Marketplace 返回默认站点
+func (s *AmazonSpider) Marketplace() Marketplace {
+	return s.marketplace
+}
+
+// 🔧 marketplaceOr 返回单次调用指定的站点，未指定时返回默认站点
+func (s *AmazonSpider) marketplaceOr(m Marketplace) Marketplace {
+	if m.Domain == "" {
+		return s.marketplace
+	}
+	return m
+}
+
+// 🔁 SetRetryCount 设置请求失败时的重试次数（默认2次，即最多请求 MaxRetries 次），0 表示不重试
+//
+// 只修改重试策略的次数，退避间隔等其他设置不变
+func (s *AmazonSpider) SetRetryCount(count int) *AmazonSpider {
+	s.retryPolicy.MaxAttempts = count + 1
+	return s
+}
+
+// 🔁 SetRetryPolicy 设置重试策略
+//
+// 超时、网络中断、429/5xx 按指数退避重试；除 policy.Retryable 外，
+// 狗狗页（503限流）总是重试，图片搜索在stylesnap令牌失效时重新获取令牌后重试
+func (s *AmazonSpider) SetRetryPolicy(policy retry.Policy) *AmazonSpider {
+	s.retryPolicy = policy
+	return s
+}
+
+// 🔁 withRetry 按重试策略执行fn，classifiers 为默认判断之外的可重试错误
+func (s *AmazonSpider) withRetry(ctx context.Context, fn func() error, classifiers ...retry.Classifier) error {
+	policy := s.retryPolicy
+	policy.Retryable = retry.Any(append([]retry.Classifier{policy.Retryable, isThrottled}, classifiers...)...)
+	return policy.Do(ctx, fn)
+}
+
+// 🔧 isThrottled 狗狗页通常是请求过于频繁时的503页面，与 429/5xx 一样稍后重试
+func isThrottled(err error) bool {
+	return errors.Is(err, ErrDogPage)
+}
+
+// 🔧 selectProxy 从代理配置中选出本次调用使用的代理地址
+//
+// 优先使用 https，其次 http，保证同样的配置每次选出同一个代理
+func selectProxy(proxies map[string]string) string {
+	for _, scheme := range []string{"https", "http"} {
+		if proxyURL := proxies[scheme]; proxyURL != "" {
+			return proxyURL
+		}
+	}
+	return ""
+}
+
+// 🔧 imageSearchSession 图片搜索的stylesnap令牌与出口IP绑定，
+// 使用代理池时为本次搜索绑定粘性会话，保证获取令牌和上传图片走同一代理
+func (s *AmazonSpider) imageSearchSession(ctx context.Context) context.Context {
+	if s.proxyPool == nil || proxy.SessionFromContext(ctx) != "" {
+		return ctx
+	}
+	return proxy.WithSession(ctx, fmt.Sprintf("image-search-%d", imageSearchSeq.Add(1)))
+}
+
+// imageSearchSeq 图片搜索会话序号
+var imageSearchSeq atomic.Int64
+
 // 🔍 FetchProductDetail 获取亚马逊商品详情
 //
 // 这是暴露给外部调用的主要接口，通过商品URL获取商品的详细信息
 //
 // 参数:
 //   - ctx: 上下文，用于控制请求的生命周期
-//   - productURL: 亚马逊商品URL
+//   - productURL: 亚马逊商品URL，也支持 /gp/product/、/gp/aw/d/ 和短链接（amzn.to等）
 //
 // 返回:
 //   - ProductResult: 商品详情结果
 //   - error: 错误信息，如果没有错误则为nil
 func (s *AmazonSpider) FetchProductDetail(ctx context.Context, productURL string) (ProductResult, error) {
-	// ✅ 验证URL是否为有效的亚马逊商品链接
+	// ✅ 验证URL是否为有效的亚马逊商品链接，其他写法转换为 /dp/ASIN
 	if validURLs := s.util.URLCheck([]string{productURL}); len(validURLs) == 0 {
-		return ProductResult{}, fmt.Errorf("❌ 无效的亚马逊URL: %s", productURL)
+		canonical, err := s.ResolveProductURL(ctx, productURL)
+		if err != nil {
+			return ProductResult{}, err
+		}
+		productURL = canonical
 	}
 
-	// 📡 发送HTTP请求获取页面内容
-	resp, err := s.client.R().
-		SetContext(ctx).
-		Get(productURL)
+	// 🌍 按商品URL的域名识别站点，使用对应语言的请求头
+	mp, ok := MarketplaceByDomain(productURL)
+	if !ok {
+		mp = s.marketplace
+	}
 
+	// 🔁 超时、网络中断、429/5xx 按重试策略重试
+	var result ProductResult
+	err := s.withRetry(ctx, func() (err error) {
+		result, err = s.fetchProductPage(ctx, mp, productURL)
+		return err
+	})
 	if err != nil {
-		return ProductResult{}, fmt.Errorf("❌ 请求失败: %w", err)
+		return ProductResult{}, err
 	}
+	return result, nil
+}
 
-	if resp.StatusCode() != 200 {
-		return ProductResult{}, fmt.Errorf("❌ HTTP请求失败，状态码: %d", resp.StatusCode())
+// 🔧 fetchProductPage 请求并解析单个商品详情页
+func (s *AmazonSpider) fetchProductPage(ctx context.Context, mp Marketplace, productURL string) (ProductResult, error) {
+	body, err := s.fetchProductHTML(ctx, mp, productURL)
+	if err != nil {
+		return ProductResult{}, err
 	}
 
 	// 📝 提取商品详情
-	result := s.extractor.GetProductDetail(productURL, string(resp.Body()))
+	result := s.extractor.GetProductDetail(productURL, body)
+	if result.Title == "" {
+		return ProductResult{}, &PageError{Platform: PlatformName, Kind: ErrNotProductPage, URL: productURL, StatusCode: http.StatusOK}
+	}
 	return result, nil
 }
 
+// 🔧 fetchProductHTML 请求商品详情页，返回页面HTML
+func (s *AmazonSpider) fetchProductHTML(ctx context.Context, mp Marketplace, productURL string) (string, error) {
+	// 📡 发送HTTP请求获取页面内容
+	resp, err := s.execute(ctx, "", func(req *resty.Request) (*resty.Response, error) {
+		return req.SetHeaders(mp.LocaleHeaders()).Get(productURL)
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("❌ 请求失败: %w", err)
+	}
+
+	// 🚧 识别验证码、狗狗页、登录墙等异常页面
+	if err := checkPage(resp); err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode() != 200 {
+		return "", errs.NewHTTPError(PlatformName, productURL, resp.StatusCode(), resp.Body())
+	}
+	return string(resp.Body()), nil
+}
+
 // 🔍 SearchProductsByImageURL 通过在线图片URL搜索相关商品
 //
 // 这个接口通过图片URL搜索亚马逊上的相关商品
@@ -94,38 +364,19 @@ func (s *AmazonSpider) FetchProductDetail(ctx context.Context, productURL string
 //   - []ImageSearchProduct: 搜索到的商品列表
 //   - error: 错误信息，如果没有错误则为nil
 func (s *AmazonSpider) SearchProductsByImageURL(ctx context.Context, imageURL string, proxies map[string]string) ([]ImageSearchProduct, error) {
-	for attempt := 0; attempt < MaxRetries; attempt++ {
-		// 获取stylesnap值
-		stylesnapValue, err := s.getStylesnapValue(ctx, proxies)
-		if err != nil {
-			if attempt == MaxRetries-1 {
-				return nil, fmt.Errorf("❌ 获取stylesnap值失败: %w", err)
-			}
-			continue
-		}
-
-		// 下载图片
-		imageData, err := s.downloadImage(ctx, imageURL)
-		if err != nil {
-			if attempt == MaxRetries-1 {
-				return nil, fmt.Errorf("❌ 下载图片失败: %w", err)
-			}
-			continue
-		}
-
-		// 上传图片并搜索
-		products, err := s.uploadImageAndSearch(ctx, imageData, stylesnapValue, proxies)
-		if err != nil {
-			if attempt == MaxRetries-1 {
-				return nil, fmt.Errorf("❌ 图片搜索失败: %w", err)
-			}
-			continue
-		}
+	ctx = s.imageSearchSession(ctx)
 
-		return products, nil
+	// 下载图片（只下载一次，之后的重试只重新上传）
+	var imageData []byte
+	err := s.withRetry(ctx, func() (err error) {
+		imageData, err = s.downloadImage(ctx, imageURL, proxies)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("❌ 下载图片失败: %w", err)
 	}
 
-	return nil, fmt.Errorf("❌ 达到最大重试次数，搜索失败")
+	return s.searchByImage(ctx, imageData, proxies)
 }
 
 // 🔍 SearchProductsByImageData 通过本地图片数据搜索相关商品
@@ -141,60 +392,68 @@ func (s *AmazonSpider) SearchProductsByImageURL(ctx context.Context, imageURL st
 //   - []ImageSearchProduct: 搜索到的商品列表
 //   - error: 错误信息，如果没有错误则为nil
 func (s *AmazonSpider) SearchProductsByImageData(ctx context.Context, imageData []byte, proxies map[string]string) ([]ImageSearchProduct, error) {
-	for attempt := 0; attempt < MaxRetries; attempt++ {
+	return s.searchByImage(s.imageSearchSession(ctx), imageData, proxies)
+}
+
+// 🔧 searchByImage 获取stylesnap令牌并上传图片搜索
+//
+// 每次尝试都重新获取令牌，令牌缺失或失效时按重试策略重试
+func (s *AmazonSpider) searchByImage(ctx context.Context, imageData []byte, proxies map[string]string) ([]ImageSearchProduct, error) {
+	var products []ImageSearchProduct
+	err := s.withRetry(ctx, func() error {
 		// 获取stylesnap值
 		stylesnapValue, err := s.getStylesnapValue(ctx, proxies)
 		if err != nil {
-			if attempt == MaxRetries-1 {
-				return nil, fmt.Errorf("❌ 获取stylesnap值失败: %w", err)
-			}
-			continue
+			return fmt.Errorf("❌ 获取stylesnap值失败: %w", err)
 		}
 
 		// 上传图片并搜索
-		products, err := s.uploadImageAndSearch(ctx, imageData, stylesnapValue, proxies)
+		products, err = s.uploadImageAndSearch(ctx, imageData, stylesnapValue, proxies)
 		if err != nil {
-			if attempt == MaxRetries-1 {
-				return nil, fmt.Errorf("❌ 图片搜索失败: %w", err)
-			}
-			continue
+			return fmt.Errorf("❌ 图片搜索失败: %w", err)
 		}
-
-		return products, nil
+		return nil
+	}, isStaleStylesnap)
+	if err != nil {
+		return nil, err
 	}
+	return products, nil
+}
+
+// errStylesnapNotFound 页面中没有stylesnap令牌
+var errStylesnapNotFound = errors.New("找不到stylesnap，请重试")
 
-	return nil, fmt.Errorf("❌ 达到最大重试次数，搜索失败")
+// ErrStylesnapExpired 上传接口拒绝了stylesnap令牌（令牌过期或与出口IP不匹配），重新获取令牌后可重试，属于 errs.ErrBlocked
+var ErrStylesnapExpired = errs.NewKind(errs.ErrBlocked, "stylesnap令牌已失效")
+
+// 🔧 isStaleStylesnap 判断是否为令牌缺失或失效，重新获取令牌后可能成功
+func isStaleStylesnap(err error) bool {
+	return errors.Is(err, errStylesnapNotFound) || errors.Is(err, ErrStylesnapExpired)
 }
 
 // 🔧 getStylesnapValue 获取stylesnap值用于图片上传请求
 func (s *AmazonSpider) getStylesnapValue(ctx context.Context, proxies map[string]string) (string, error) {
-	// 构建请求
-	req := s.client.R().SetContext(ctx)
-
-	// 设置代理
-	if len(proxies) > 0 {
-		for scheme, proxy := range proxies {
-			if scheme == "http" || scheme == "https" {
-				s.client.SetProxy(proxy)
-				break
-			}
-		}
-	}
-
-	// 发送请求
-	resp, err := req.Get(AmazonShopLookURL)
+	// 发送请求（代理只作用于本次调用）
+	mp := s.marketplace
+	resp, err := s.execute(ctx, selectProxy(proxies), func(req *resty.Request) (*resty.Response, error) {
+		return req.SetHeaders(mp.LocaleHeaders()).Get(mp.ShopLookURL())
+	})
 	if err != nil {
 		return "", fmt.Errorf("请求失败: %w", err)
 	}
 
+	if err := checkPage(resp); err != nil {
+		return "", err
+	}
+
 	if resp.StatusCode() != 200 {
-		return "", fmt.Errorf("状态码: %d", resp.StatusCode())
+		return "", errs.NewHTTPError(PlatformName, mp.ShopLookURL(), resp.StatusCode(), resp.Body())
 	}
 
 	// 解析HTML获取stylesnap值
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(resp.Body())))
 	if err != nil {
-		return "", fmt.Errorf("解析HTML失败: %w", err)
+		return "", &errs.ParseError{Platform: PlatformName, What: "HTML", Err: err}
 	}
 
 	var stylesnapValue string
@@ -205,25 +464,24 @@ func (s *AmazonSpider) getStylesnapValue(ctx context.Context, proxies map[string
 	})
 
 	if stylesnapValue == "" {
-		return "", fmt.Errorf("找不到stylesnap，请重试")
+		return "", &errs.ParseError{Platform: PlatformName, What: "stylesnap令牌", Err: errStylesnapNotFound}
 	}
 
-	fmt.Printf("请求头加密参数：【%s】\n", stylesnapValue)
 	return stylesnapValue, nil
 }
 
 // 🔧 downloadImage 下载图片并返回二进制数据
-func (s *AmazonSpider) downloadImage(ctx context.Context, imageURL string) ([]byte, error) {
-	resp, err := s.client.R().
-		SetContext(ctx).
-		Get(imageURL)
+func (s *AmazonSpider) downloadImage(ctx context.Context, imageURL string, proxies map[string]string) ([]byte, error) {
+	resp, err := s.execute(ctx, selectProxy(proxies), func(req *resty.Request) (*resty.Response, error) {
+		return req.Get(imageURL)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("下载图片失败: %w", err)
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("无法下载图片，状态码: %d", resp.StatusCode())
+		return nil, errs.NewHTTPError(PlatformName, imageURL, resp.StatusCode(), resp.Body())
 	}
 
 	return resp.Body(), nil
@@ -231,36 +489,33 @@ func (s *AmazonSpider) downloadImage(ctx context.Context, imageURL string) ([]by
 
 // 🔧 uploadImageAndSearch 上传图片并获取搜索结果
 func (s *AmazonSpider) uploadImageAndSearch(ctx context.Context, imageData []byte, stylesnapValue string, proxies map[string]string) ([]ImageSearchProduct, error) {
-	// 设置代理
-	if len(proxies) > 0 {
-		for scheme, proxy := range proxies {
-			if scheme == "http" || scheme == "https" {
-				s.client.SetProxy(proxy)
-				break
-			}
-		}
-	}
-
-	// 构建请求
-	req := s.client.R().
-		SetContext(ctx).
-		SetQueryParam("stylesnapToken", stylesnapValue).
-		SetFileReader("explore-looks.jpg", "explore-looks.jpg", bytes.NewReader(imageData))
-
-	// 发送POST请求
-	resp, err := req.Post(AmazonStyleSnapUploadURL)
+	// 发送POST请求（代理只作用于本次调用）
+	mp := s.marketplace
+	resp, err := s.execute(ctx, selectProxy(proxies), func(req *resty.Request) (*resty.Response, error) {
+		return req.
+			SetHeaders(mp.LocaleHeaders()).
+			SetQueryParam("stylesnapToken", stylesnapValue).
+			SetFileReader("explore-looks.jpg", "explore-looks.jpg", bytes.NewReader(imageData)).
+			Post(mp.StyleSnapUploadURL())
+	})
 	if err != nil {
 		return nil, fmt.Errorf("上传图片失败: %w", err)
 	}
 
+	// 🔑 令牌过期或与出口IP不匹配时上传接口返回 400/401/403
+	switch resp.StatusCode() {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return nil, &PageError{Platform: PlatformName, Kind: ErrStylesnapExpired, URL: mp.StyleSnapUploadURL(), StatusCode: resp.StatusCode()}
+	}
+
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("图片上传失败，状态码: %d", resp.StatusCode())
+		return nil, errs.NewHTTPError(PlatformName, mp.StyleSnapUploadURL(), resp.StatusCode(), resp.Body())
 	}
 
 	// 解析响应
 	var styleSnapResp StyleSnapResponse
 	if err := json.Unmarshal(resp.Body(), &styleSnapResp); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %w", err)
+		return nil, &errs.ParseError{Platform: PlatformName, What: "图片搜索响应", Err: err}
 	}
 
 	// 提取搜索结果
@@ -269,9 +524,17 @@ func (s *AmazonSpider) uploadImageAndSearch(ctx context.Context, imageData []byt
 		products = styleSnapResp.SearchResults[0].BBXASINMetadataList
 	}
 
-	// 添加商品链接
+	// 添加商品链接（与搜索所用站点一致），按站点格式解析价格
 	for i := range products {
-		products[i].LinkURL = AmazonProductDetailPrefixURL + products[i].ASIN
+		products[i].LinkURL = mp.ProductURL(products[i].ASIN)
+		if money, ok := ParseMoney(products[i].Price, mp); ok {
+			products[i].PriceMoney = &money
+		}
+		if products[i].ListPrice != nil {
+			if money, ok := ParseMoney(*products[i].ListPrice, mp); ok {
+				products[i].ListPriceMoney = &money
+			}
+		}
 	}
 
 	return products, nil
@@ -0,0 +1,144 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var (
+	// ASIN格式校验
+	asinPattern = regexp.MustCompile(`^[A-Z0-9]{10}$`)
+	// 链接中的ASIN：/dp/、/gp/product/、/gp/aw/d/、/product-reviews/ 等写法
+	asinPathPattern = regexp.MustCompile(`(?i)/(?:dp|gp/product|gp/aw/d|gp/offer-listing|product-reviews)/([A-Z0-9]{10})(?:[/?#]|$)`)
+)
+
+// shortLinkHosts 亚马逊短链接域名，需要请求后才能得到商品链接
+var shortLinkHosts = map[string]bool{
+	"amzn.to":   true,
+	"amzn.eu":   true,
+	"amzn.asia": true,
+	"a.co":      true,
+}
+
+// IsValidASIN 判断是否为有效的ASIN（10位大写字母或数字）
+func IsValidASIN(asin string) bool {
+	return asinPattern.MatchString(asin)
+}
+
+// 🔍 ExtractASIN 从亚马逊链接中提取ASIN
+//
+// 支持 /dp/ASIN、/gp/product/ASIN、/gp/aw/d/ASIN、/product-reviews/ASIN 等写法，
+// 也可以直接传入ASIN；短链接（amzn.to等）需要先用 ResolveProductURL 解析
+//
+// 参数:
+//   - rawURL: 商品链接或ASIN
+//
+// 返回:
+//   - string: 大写的ASIN
+//   - bool: 是否提取成功
+func ExtractASIN(rawURL string) (string, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+	if asin := strings.ToUpper(rawURL); IsValidASIN(asin) {
+		return asin, true
+	}
+	if match := asinPathPattern.FindStringSubmatch(rawURL); len(match) == 2 {
+		return strings.ToUpper(match[1]), true
+	}
+	return "", false
+}
+
+// 🔗 CanonicalURL 将商品链接转换为 https://<站点域名>/dp/ASIN 形式
+//
+// 去掉标题路径、ref和查询参数，同一商品的不同链接得到相同结果，可用作去重键；
+// 不在预置站点中的亚马逊域名（如 amazon.ca）保留原域名
+//
+// 参数:
+//   - rawURL: 商品链接
+//
+// 返回:
+//   - string: 规范化后的商品链接
+//   - bool: 链接不是亚马逊商品链接时返回false
+func CanonicalURL(rawURL string) (string, bool) {
+	asin, ok := ExtractASIN(rawURL)
+	if !ok {
+		return "", false
+	}
+	if mp, ok := MarketplaceByDomain(rawURL); ok {
+		return mp.ProductURL(asin), true
+	}
+
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || !strings.Contains(u.Hostname(), "amazon.") {
+		return "", false
+	}
+	return "https://" + strings.ToLower(u.Hostname()) + "/dp/" + asin, true
+}
+
+// isShortLink 判断是否为亚马逊短链接
+func isShortLink(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	return shortLinkHosts[strings.ToLower(strings.TrimPrefix(u.Hostname(), "www."))]
+}
+
+// 🔗 ResolveProductURL 将任意形式的商品链接转换为规范的 /dp/ASIN 链接
+//
+// 短链接（amzn.to、a.co等）会发起请求并跟随重定向，其他链接不发起请求
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求的生命周期
+//   - rawURL: 商品链接或短链接
+//
+// 返回:
+//   - string: 规范化后的商品链接
+//   - error: 链接无法识别或短链接未指向商品页时返回错误
+func (s *AmazonSpider) ResolveProductURL(ctx context.Context, rawURL string) (string, error) {
+	if canonical, ok := CanonicalURL(rawURL); ok {
+		return canonical, nil
+	}
+	if !isShortLink(rawURL) {
+		return "", fmt.Errorf("❌ 无效的亚马逊URL: %s", rawURL)
+	}
+
+	resp, err := s.execute(ctx, "", func(req *resty.Request) (*resty.Response, error) {
+		return req.Get(rawURL)
+	})
+	if err != nil {
+		return "", fmt.Errorf("❌ 解析短链接失败: %w", err)
+	}
+
+	finalURL := rawURL
+	if raw := resp.RawResponse; raw != nil && raw.Request != nil && raw.Request.URL != nil {
+		finalURL = raw.Request.URL.String()
+	}
+	canonical, ok := CanonicalURL(finalURL)
+	if !ok {
+		return "", &PageError{Platform: PlatformName, Kind: ErrNotProductPage, URL: rawURL, FinalURL: finalURL, StatusCode: resp.StatusCode()}
+	}
+	return canonical, nil
+}
+
+// 🔍 FetchProductByASIN 按ASIN获取指定站点的商品详情
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求的生命周期
+//   - mp: 商品所在站点，为空时使用默认站点
+//   - asin: 商品ASIN
+//
+// 返回:
+//   - ProductResult: 商品详情结果，LinkURL 为 mp.ProductURL(asin)
+//   - error: 错误信息，如果没有错误则为nil
+func (s *AmazonSpider) FetchProductByASIN(ctx context.Context, mp Marketplace, asin string) (ProductResult, error) {
+	asin = strings.ToUpper(strings.TrimSpace(asin))
+	if !IsValidASIN(asin) {
+		return ProductResult{}, fmt.Errorf("❌ 无效的ASIN: %s", asin)
+	}
+	return s.FetchProductDetail(ctx, s.marketplaceOr(mp).ProductURL(asin))
+}
@@ -0,0 +1,216 @@
+package amazon
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 📦 FetchProductDetails 批量获取商品详情
+//
+// 使用固定数量的worker并发抓取，可按站点限制并发数和请求间隔；
+// 每个商品完成后调用 opts.OnResult（如已设置），全部完成后返回
+//
+// 参数:
+//   - ctx: 上下文，取消后未开始的商品返回 ctx.Err()
+//   - urls: 商品URL列表，支持 FetchProductDetail 接受的所有链接形式
+//   - opts: 并发、限速与输出顺序选项
+//
+// 返回:
+//   - []ProductResult: 与urls一一对应的商品详情，失败的位置为空结果
+//   - []error: 与urls一一对应的错误，成功的位置为nil
+func (s *AmazonSpider) FetchProductDetails(ctx context.Context, urls []string, opts BatchOptions) ([]ProductResult, []error) {
+	products := make([]ProductResult, len(urls))
+	failures := make([]error, len(urls))
+	s.runBatch(ctx, urls, opts, func(result BatchResult) {
+		products[result.Index] = result.Product
+		failures[result.Index] = result.Err
+	})
+	return products, failures
+}
+
+// 📦 StreamProductDetails 批量获取商品详情，逐个通过channel输出结果
+//
+// 全部商品完成后关闭channel。调用方应读完channel；不再读取时取消ctx，
+// 取消后尚未输出的结果会被丢弃，channel随后关闭
+//
+// 参数:
+//   - ctx: 上下文，用于控制整批请求的生命周期
+//   - urls: 商品URL列表
+//   - opts: 并发、限速与输出顺序选项
+//
+// 返回:
+//   - <-chan BatchResult: 每个商品一个结果，Ordered为true时按输入顺序输出
+func (s *AmazonSpider) StreamProductDetails(ctx context.Context, urls []string, opts BatchOptions) <-chan BatchResult {
+	out := make(chan BatchResult)
+	go func() {
+		defer close(out)
+		s.runBatch(ctx, urls, opts, func(result BatchResult) {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case out <- result:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return out
+}
+
+// 🔧 runBatch 执行批量抓取，按选项的顺序对每个结果调用 OnResult 和 emit
+func (s *AmazonSpider) runBatch(ctx context.Context, urls []string, opts BatchOptions, emit func(BatchResult)) {
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = ChunkSize
+	}
+	if workers > len(urls) {
+		workers = len(urls)
+	}
+	limiter := newHostLimiter(opts.PerHostConcurrency, opts.PerHostInterval)
+
+	jobs := make(chan int)
+	results := make(chan BatchResult)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				results <- s.fetchBatchItem(ctx, limiter, index, urls[index])
+			}
+		}()
+	}
+	go func() {
+		for index := range urls {
+			jobs <- index
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	deliver := func(result BatchResult) {
+		if opts.OnResult != nil {
+			opts.OnResult(result)
+		}
+		emit(result)
+	}
+
+	// 有序模式下缓存提前完成的结果，等前面的商品完成后再输出
+	pending := make(map[int]BatchResult)
+	next := 0
+	for result := range results {
+		if !opts.Ordered {
+			deliver(result)
+			continue
+		}
+		pending[result.Index] = result
+		for {
+			ready, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			deliver(ready)
+			next++
+		}
+	}
+}
+
+// 🔧 fetchBatchItem 在站点限制内获取单个商品
+func (s *AmazonSpider) fetchBatchItem(ctx context.Context, limiter *hostLimiter, index int, productURL string) BatchResult {
+	result := BatchResult{Index: index, URL: productURL}
+	release, err := limiter.acquire(ctx, hostOf(productURL))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer release()
+
+	result.Product, result.Err = s.FetchProductDetail(ctx, productURL)
+	return result
+}
+
+// hostLimiter 按站点限制并发数和请求间隔
+type hostLimiter struct {
+	concurrency int
+	interval    time.Duration
+
+	mu    sync.Mutex
+	slots map[string]chan struct{} // 每个站点的并发槽位
+	next  map[string]time.Time     // 每个站点下一次允许发起请求的时间
+}
+
+// newHostLimiter 创建站点限制器，concurrency和interval为0时不做对应限制
+func newHostLimiter(concurrency int, interval time.Duration) *hostLimiter {
+	return &hostLimiter{
+		concurrency: concurrency,
+		interval:    interval,
+		slots:       make(map[string]chan struct{}),
+		next:        make(map[string]time.Time),
+	}
+}
+
+// acquire 占用站点的一个并发槽位并等待到允许发起请求的时间，返回释放槽位的函数
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var slot chan struct{}
+	if l.concurrency > 0 {
+		l.mu.Lock()
+		slot = l.slots[host]
+		if slot == nil {
+			slot = make(chan struct{}, l.concurrency)
+			l.slots[host] = slot
+		}
+		l.mu.Unlock()
+
+		select {
+		case slot <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if slot != nil {
+			<-slot
+		}
+	}
+
+	if l.interval > 0 {
+		// 预约下一个时间点，同一站点的请求按间隔依次发出
+		l.mu.Lock()
+		at := l.next[host]
+		if now := time.Now(); at.Before(now) {
+			at = now
+		}
+		l.next[host] = at.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait := time.Until(at); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return release, nil
+}
+
+// hostOf 返回链接的站点（去掉 www. 前缀），无法解析时返回原始链接
+func hostOf(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Hostname() == "" {
+		return rawURL
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,140 @@
+package amazon
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// 🚧 亚马逊反爬/异常页面
+//
+// 亚马逊在拦截请求时通常仍返回200，解析后只会得到空结果。
+// 以下错误用于区分这些页面，调用方可据此更换代理、降低频率或直接跳过:
+//
+//	if errors.Is(err, amazon.ErrCaptcha) { ... }       // 更换代理后重试
+//	var pageErr *amazon.PageError
+//	if errors.As(err, &pageErr) { log.Println(pageErr.StatusCode, pageErr.FinalURL) }
+var (
+	// ErrCaptcha 验证码页面（"Enter the characters you see below"），属于 errs.ErrBlocked
+	ErrCaptcha = errs.NewKind(errs.ErrBlocked, "亚马逊返回验证码页面")
+	// ErrDogPage 503错误页（狗狗页），通常是请求过于频繁被限流，属于 errs.ErrBlocked
+	ErrDogPage = errs.NewKind(errs.ErrBlocked, "亚马逊返回错误页面（dog page）")
+	// ErrSignInRequired 页面要求登录（未登录的异常请求会被跳转到登录页），属于 errs.ErrBlocked
+	ErrSignInRequired = errs.NewKind(errs.ErrBlocked, "亚马逊要求登录")
+	// ErrPageNotFound 页面不存在（商品下架或链接错误），属于 errs.ErrNotFound
+	ErrPageNotFound = errs.NewKind(errs.ErrNotFound, "亚马逊页面不存在")
+	// ErrRegionRedirect 被重定向到其他地区站点（出口IP与站点地区不符），属于 errs.ErrBlocked
+	ErrRegionRedirect = errs.NewKind(errs.ErrBlocked, "亚马逊重定向到其他地区站点")
+	// ErrNotProductPage 页面不是商品详情页（未找到商品标题），属于 errs.ErrParse
+	ErrNotProductPage = errs.NewKind(errs.ErrParse, "页面不是亚马逊商品详情页")
+)
+
+// 页面特征
+var (
+	captchaMarkers = []string{
+		"/errors/validateCaptcha",
+		"Enter the characters you see below",
+		`id="captchacharacters"`,
+	}
+	dogPageMarkers = []string{
+		"api-services-support@amazon.com",
+		"Sorry! Something went wrong!",
+	}
+	notFoundMarkers = []string{
+		"Sorry! We couldn't find that page",
+		"The Web address you entered is not a functioning page on our site",
+	}
+	signInMarkers = []string{
+		`name="signIn"`,
+		`id="ap_email"`,
+	}
+)
+
+// PageError 亚马逊异常页面错误
+// 📋 Kind 为上面的哨兵错误之一，可通过 errors.Is 判断
+type PageError = errs.PageError
+
+// IsBlocked 判断错误是否为反爬拦截（验证码、狗狗页、登录墙或地区重定向），等同于 errors.Is(err, errs.ErrBlocked)
+// 🔄 这类错误与出口IP相关，更换代理或降低频率后通常可以恢复
+func IsBlocked(err error) bool {
+	return errors.Is(err, errs.ErrBlocked)
+}
+
+// 🔍 DetectPage 识别验证码、狗狗页、登录墙、页面不存在和地区重定向
+//
+// 参数:
+//   - statusCode: HTTP状态码
+//   - requestURL: 请求地址
+//   - finalURL: 重定向后的最终地址，没有重定向时与requestURL相同或为空
+//   - body: 响应内容
+//
+// 返回:
+//   - error: 识别到异常页面时返回 *PageError，否则返回nil
+func DetectPage(statusCode int, requestURL, finalURL string, body []byte) error {
+	var kind error
+	switch {
+	case containsAny(body, captchaMarkers):
+		kind = ErrCaptcha
+	case statusCode == 503 || containsAny(body, dogPageMarkers):
+		kind = ErrDogPage
+	case statusCode == 404 || containsAny(body, notFoundMarkers):
+		kind = ErrPageNotFound
+	case strings.Contains(finalURL, "/ap/signin") || containsAny(body, signInMarkers):
+		kind = ErrSignInRequired
+	case isRegionRedirect(requestURL, finalURL):
+		kind = ErrRegionRedirect
+	default:
+		return nil
+	}
+
+	return &PageError{
+		Platform:   PlatformName,
+		Kind:       kind,
+		URL:        requestURL,
+		FinalURL:   finalURL,
+		StatusCode: statusCode,
+	}
+}
+
+// checkPage 识别响应是否为异常页面 (私有方法)
+func checkPage(resp *resty.Response) error {
+	requestURL := resp.Request.URL
+	if raw := resp.Request.RawRequest; raw != nil && raw.URL != nil {
+		requestURL = raw.URL.String()
+	}
+	finalURL := requestURL
+	if raw := resp.RawResponse; raw != nil && raw.Request != nil && raw.Request.URL != nil {
+		finalURL = raw.Request.URL.String()
+	}
+	return DetectPage(resp.StatusCode(), requestURL, finalURL, resp.Body())
+}
+
+// containsAny 判断内容是否包含任一特征
+func containsAny(body []byte, markers []string) bool {
+	for _, marker := range markers {
+		if bytes.Contains(body, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRegionRedirect 判断是否被重定向到其他域名（如 amazon.com -> amazon.co.uk）
+func isRegionRedirect(requestURL, finalURL string) bool {
+	if finalURL == "" || finalURL == requestURL {
+		return false
+	}
+	from, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	to, err := url.Parse(finalURL)
+	if err != nil {
+		return false
+	}
+	return strings.TrimPrefix(from.Hostname(), "www.") != strings.TrimPrefix(to.Hostname(), "www.")
+}
@@ -1,10 +1,17 @@
 package amazon
 
+import "github.com/xieburoucoco/spider-hub/fingerprint"
+
 // 参数常量
 const (
 	Keyword  = "keyword"
 	Page     = "page"
 	PageSize = "page_size"
+	MaxPages = "max_pages"
+	Star     = "star"
+	SortBy   = "sort_by"
+	Verified = "verified"
+	Details  = "details"
 )
 
 // 返回格式常量
@@ -25,29 +32,26 @@ const (
 	RequestTimeout      = 30
 )
 
-// Amazon请求头
-var AmazonHeaders = map[string]string{
-	"accept":                     "*/*",
-	"accept-language":            "en-US,en;q=0.6",
-	"cache-control":              "no-cache",
-	"pragma":                     "no-cache",
-	"priority":                   "u=1, i",
-	"referer":                    "https://www.amazon.com",
-	"sec-ch-ua":                  `"Chromium";v="124", "Brave";v="124", "Not-A.Brand";v="99"`,
-	"sec-ch-ua-mobile":           "?0",
-	"sec-ch-ua-platform":         `"Windows"`,
-	"sec-ch-ua-platform-version": `"10.0.0"`,
-	"sec-fetch-dest":             "empty",
-	"sec-fetch-mode":             "cors",
-	"sec-fetch-site":             "same-origin",
-	"sec-gpc":                    "1",
-	"user-agent":                 "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+// Amazon请求头中与浏览器无关的部分
+// 🎭 user-agent、sec-ch-ua、accept-language 等由浏览器指纹生成，见 AmazonSpider.SetFingerprints
+var amazonRequestHeaders = map[string]string{
+	"accept":         "*/*",
+	"cache-control":  "no-cache",
+	"pragma":         "no-cache",
+	"priority":       "u=1, i",
+	"referer":        "https://www.amazon.com",
+	"sec-fetch-dest": "empty",
+	"sec-fetch-mode": "cors",
+	"sec-fetch-site": "same-origin",
 }
 
+// Amazon请求头（默认浏览器指纹 fingerprint.DefaultProfile）
+var AmazonHeaders = fingerprint.DefaultProfile.Apply(amazonRequestHeaders)
+
 // 链接校验正则表达式
 const AmazonPattern = `https://(www|us)\.amazon.*?/.*?(d|dp)/.*?`
 
-// 任务切块大小
+// 任务切块大小（ChunkSize 同时是批量获取商品详情的默认并发数）
 const (
 	ChunkSize       = 5
 	VideoThresholds = 100
@@ -85,17 +89,30 @@ var CountriesInfo = []CountryInfo{
 	{Country: "西班牙", CurrencySymbol: "€", Language: "西班牙语", LanguageCode: "ES", WhisperLanguageCode: "es", DifyLanguage: "西班牙语", TTSVoiceName: "es-ES-ElviraNeural"},
 	{Country: "泰国", CurrencySymbol: "฿", Language: "泰语", LanguageCode: "TH", WhisperLanguageCode: "th", DifyLanguage: "泰语", TTSVoiceName: "th-TH-NiwatNeural"},
 	{Country: "越南", CurrencySymbol: "₫", Language: "越南语", LanguageCode: "VIE", WhisperLanguageCode: "vi", DifyLanguage: "越南语", TTSVoiceName: "vi-VN-NamMinhNeural"},
+	{Country: "德国", CurrencySymbol: "€", Language: "德语", LanguageCode: "DE", WhisperLanguageCode: "de", DifyLanguage: "德语", TTSVoiceName: "de-DE-KatjaNeural"},
+	{Country: "英国", CurrencySymbol: "£", Language: "英文", LanguageCode: "EN", WhisperLanguageCode: "en", DifyLanguage: "英语", TTSVoiceName: "en-GB-SoniaNeural"},
+	{Country: "意大利", CurrencySymbol: "€", Language: "意大利语", LanguageCode: "IT", WhisperLanguageCode: "it", DifyLanguage: "意大利语", TTSVoiceName: "it-IT-ElsaNeural"},
 }
 
-// 图片搜索相关URL
+// 图片搜索相关URL（美国站，其他站点见 Marketplace）
 const (
 	AmazonShopLookURL            = "https://www.amazon.com/shopthelook"
 	AmazonStyleSnapUploadURL     = "https://www.amazon.com/stylesnap/upload"
 	AmazonProductDetailPrefixURL = "https://www.amazon.com/dp/"
 )
 
+// 关键词搜索相关URL（美国站）
+const (
+	AmazonSearchURL = "https://www.amazon.com/s"
+)
+
+// 评论相关URL（美国站）
+const (
+	AmazonProductReviewsPrefixURL = "https://www.amazon.com/product-reviews/"
+)
+
 // 图片搜索配置
 const (
-	MaxRetries         = 3
+	MaxRetries         = 3 // 默认最多请求次数（包括第一次）
 	ImageSearchTimeout = 30
 )
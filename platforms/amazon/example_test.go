@@ -1,14 +1,31 @@
 package amazon
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/xieburoucoco/spider-hub/errs"
+	"github.com/xieburoucoco/spider-hub/fingerprint"
+	"github.com/xieburoucoco/spider-hub/platforms"
+	"github.com/xieburoucoco/spider-hub/proxy"
+	"github.com/xieburoucoco/spider-hub/retry"
 )
 
 // TestAmazonProductDetail 测试获取亚马逊商品详情
@@ -163,6 +180,1109 @@ func TestSearchProductsByImageData(t *testing.T) {
 	fmt.Println("  • 搜索结果可能随时间变化，建议定期更新数据")
 }
 
+// TestAmazonPlatform 测试亚马逊平台已注册到注册中心
+func TestAmazonPlatform(t *testing.T) {
+	platform, ok := platforms.Lookup(PlatformName)
+	if !ok {
+		t.Fatal("❌ amazon平台未注册")
+	}
+	if !platforms.Supports(platform, ActionProduct) || !platforms.Supports(platform, ActionImageSearch) {
+		t.Fatalf("❌ 平台能力不完整: %+v", platform.Capabilities())
+	}
+
+	// 无效URL在发出请求前即返回错误
+	_, err := platforms.Fetch(context.Background(), PlatformName, platforms.Request{Action: ActionProduct, Target: "https://example.com"})
+	if err == nil {
+		t.Fatal("❌ 无效URL应返回错误")
+	}
+	t.Logf("✅ 返回错误: %v", err)
+}
+
+// TestPerCallProxy 测试单次调用的代理不会写入共享客户端，并发使用安全（离线，建议配合 -race 运行）
+func TestPerCallProxy(t *testing.T) {
+	// 🧪 模拟代理：记录收到的 CONNECT 请求并拒绝，使请求在本地快速失败
+	newProxy := func(hits *int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(hits, 1)
+			w.WriteHeader(http.StatusForbidden)
+		}))
+	}
+	var hitsA, hitsB, direct int32
+	proxyA, proxyB := newProxy(&hitsA), newProxy(&hitsB)
+	defer proxyA.Close()
+	defer proxyB.Close()
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&direct, 1)
+	}))
+	defer target.Close()
+
+	spider := NewAmazonSpider().SetRetryCount(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		proxies := map[string]string{"http": proxyA.URL}
+		if i%2 == 1 {
+			proxies = map[string]string{"https": proxyB.URL, "http": proxyA.URL}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := spider.SearchProductsByImageData(context.Background(), []byte("img"), proxies); err == nil {
+				t.Error("❌ 代理拒绝连接时应返回错误")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&hitsA) == 0 || atomic.LoadInt32(&hitsB) == 0 {
+		t.Fatalf("❌ 每个代理都应收到请求: A=%d B=%d", atomic.LoadInt32(&hitsA), atomic.LoadInt32(&hitsB))
+	}
+	if spider.client.IsProxySet() {
+		t.Fatal("❌ 单次调用的代理不应写入共享客户端")
+	}
+
+	// 之后不带代理的请求直连目标
+	before := atomic.LoadInt32(&hitsA) + atomic.LoadInt32(&hitsB)
+	if _, err := spider.client.R().Get(target.URL); err != nil {
+		t.Fatalf("❌ 直连请求失败: %v", err)
+	}
+	if atomic.LoadInt32(&direct) != 1 || atomic.LoadInt32(&hitsA)+atomic.LoadInt32(&hitsB) != before {
+		t.Fatal("❌ 不带代理的请求不应经过代理")
+	}
+	t.Logf("✅ 代理A %d 次, 代理B %d 次, 直连 %d 次", atomic.LoadInt32(&hitsA), atomic.LoadInt32(&hitsB), atomic.LoadInt32(&direct))
+}
+
+// TestSelectProxy 测试代理选择优先 https 且结果确定
+func TestSelectProxy(t *testing.T) {
+	cases := []struct {
+		proxies map[string]string
+		want    string
+	}{
+		{nil, ""},
+		{map[string]string{"http": "http://a:1"}, "http://a:1"},
+		{map[string]string{"http": "http://a:1", "https": "http://b:2"}, "http://b:2"},
+		{map[string]string{"socks": "socks5://c:3"}, ""},
+	}
+	for _, c := range cases {
+		if got := selectProxy(c.proxies); got != c.want {
+			t.Errorf("❌ selectProxy(%v) = %q, 期望 %q", c.proxies, got, c.want)
+		}
+	}
+}
+
+// TestDetectPage 测试识别反爬和异常页面（离线）
+func TestDetectPage(t *testing.T) {
+	const productURL = "https://www.amazon.com/dp/B09XS7JWHH"
+	cases := []struct {
+		name     string
+		status   int
+		finalURL string
+		body     string
+		want     error
+	}{
+		{"商品页", 200, productURL, `<span id="productTitle">Camera</span>`, nil},
+		{"验证码", 200, productURL, `<form action="/errors/validateCaptcha"><h4>Enter the characters you see below</h4></form>`, ErrCaptcha},
+		{"狗狗页", 503, productURL, `To discuss automated access to Amazon data please contact api-services-support@amazon.com.`, ErrDogPage},
+		{"页面不存在", 404, productURL, `<img alt="Sorry! We couldn't find that page.">`, ErrPageNotFound},
+		{"登录墙", 200, "https://www.amazon.com/ap/signin?openid.return_to=x", `<form name="signIn">`, ErrSignInRequired},
+		{"地区重定向", 200, "https://www.amazon.co.uk/dp/B09XS7JWHH", `<span id="productTitle">Camera</span>`, ErrRegionRedirect},
+		{"同站www重定向", 200, "https://amazon.com/dp/B09XS7JWHH", ``, nil},
+	}
+
+	for _, c := range cases {
+		err := DetectPage(c.status, productURL, c.finalURL, []byte(c.body))
+		if c.want == nil {
+			if err != nil {
+				t.Errorf("❌ %s: 不应识别为异常页面: %v", c.name, err)
+			}
+			continue
+		}
+
+		var pageErr *PageError
+		if !errors.Is(err, c.want) || !errors.As(err, &pageErr) || pageErr.StatusCode != c.status {
+			t.Errorf("❌ %s: 期望 %v，实际 %v", c.name, c.want, err)
+		}
+	}
+
+	if !IsBlocked(DetectPage(200, productURL, "", []byte("Enter the characters you see below"))) || IsBlocked(DetectPage(404, productURL, "", nil)) {
+		t.Fatal("❌ IsBlocked 判断错误")
+	}
+
+	// 🏷️ 平台错误归入通用错误类别
+	if err := DetectPage(503, productURL, "", nil); !errors.Is(err, errs.ErrBlocked) {
+		t.Fatalf("❌ 狗狗页应属于 errs.ErrBlocked: %v", err)
+	}
+	if err := DetectPage(404, productURL, "", nil); !errors.Is(err, errs.ErrNotFound) || errors.Is(err, errs.ErrBlocked) {
+		t.Fatalf("❌ 页面不存在应属于 errs.ErrNotFound: %v", err)
+	}
+	for _, kind := range []error{ErrCaptcha, ErrDogPage, ErrSignInRequired, ErrRegionRedirect} {
+		if !errors.Is(kind, errs.ErrBlocked) || errors.Is(kind, errs.ErrNotFound) {
+			t.Errorf("❌ %v 应属于 errs.ErrBlocked", kind)
+		}
+	}
+	if err := DetectPage(200, productURL, "https://www.amazon.co.uk/dp/B09XS7JWHH", nil); !IsBlocked(err) || !errors.Is(err, ErrRegionRedirect) {
+		t.Fatalf("❌ 地区重定向应视为拦截: %v", err)
+	}
+	if err := DetectPage(200, productURL, "", []byte(`<input id="ap_email">`)); !IsBlocked(err) || !errors.Is(err, ErrSignInRequired) {
+		t.Fatalf("❌ 登录墙应视为拦截: %v", err)
+	}
+}
+
+// TestCheckPageRedirect 测试从响应中识别跨站重定向（离线）
+func TestCheckPageRedirect(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<span id="productTitle">Camera</span>`)
+	}))
+	defer target.Close()
+	// 127.0.0.1 -> localhost 模拟 amazon.com -> amazon.co.uk
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace(target.URL, "127.0.0.1", "localhost", 1)+r.URL.Path, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	spider := NewAmazonSpider().SetRetryCount(0)
+	resp, err := spider.client.R().Get(redirect.URL + "/dp/B09XS7JWHH")
+	if err != nil {
+		t.Fatalf("❌ 请求失败: %v", err)
+	}
+
+	var pageErr *PageError
+	if err := checkPage(resp); !errors.As(err, &pageErr) || !errors.Is(err, ErrRegionRedirect) {
+		t.Fatalf("❌ 期望地区重定向错误，实际 %v", err)
+	}
+	if !strings.HasPrefix(pageErr.URL, redirect.URL) || !strings.Contains(pageErr.FinalURL, "localhost") {
+		t.Fatalf("❌ 重定向地址错误: %+v", pageErr)
+	}
+	t.Logf("✅ 识别到跨站重定向: %s", pageErr.FinalURL)
+
+	resp, err = spider.client.R().Get(target.URL + "/dp/B09XS7JWHH")
+	if err != nil || checkPage(resp) != nil {
+		t.Fatalf("❌ 正常页面不应报错: %v, %v", err, checkPage(resp))
+	}
+}
+
+// searchPageHTML 关键词搜索结果页示例
+const searchPageHTML = `<html><body>
+<div data-component-type="s-search-result" data-asin="B0DFW4RR1H">
+  <span class="puis-sponsored-label-text">Sponsored</span>
+  <img class="s-image" src="https://m.media-amazon.com/images/I/71abc._AC_UL320_.jpg">
+  <h2><a href="/dp/B0DFW4RR1H"><span>AiTechny Digital Camera for Kids</span></a></h2>
+  <span class="a-icon-alt">4.2 out of 5 stars</span>
+  <a href="/dp/B0DFW4RR1H#customerReviews"><span class="a-size-base s-underline-text">1,471</span></a>
+  <span class="a-price"><span class="a-offscreen">$36.79</span></span>
+  <span class="a-price a-text-price"><span class="a-offscreen">$45.99</span></span>
+</div>
+<div data-component-type="s-search-result" data-asin="B0DHKGWYVG">
+  <span class="a-badge-text">Best Seller</span>
+  <h2><span>Lecran Digital Camera</span></h2>
+  <span class="a-icon-alt">4.6 out of 5 stars</span>
+  <span class="a-size-base s-underline-text">627</span>
+  <span class="a-price"><span class="a-offscreen">$1,036.50</span></span>
+</div>
+<div data-component-type="s-search-result" data-asin=""></div>
+<span class="s-pagination-item s-pagination-selected">1</span>
+<a class="s-pagination-item">2</a>
+<span class="s-pagination-item s-pagination-disabled">20</span>
+<a class="s-pagination-item s-pagination-next">Next</a>
+</body></html>`
+
+// TestGetSearchPage 测试解析关键词搜索结果页（离线）
+func TestGetSearchPage(t *testing.T) {
+	page := NewAmazonExtractor().GetSearchPage(MarketplaceUS.SearchURL(), searchPageHTML)
+
+	if len(page.Products) != 2 {
+		t.Fatalf("❌ 期望2个商品，实际 %d", len(page.Products))
+	}
+	if !page.HasNextPage || page.TotalPages != 20 {
+		t.Fatalf("❌ 分页信息错误: next=%v total=%d", page.HasNextPage, page.TotalPages)
+	}
+
+	first := page.Products[0]
+	if first.ASIN != "B0DFW4RR1H" || first.Title != "AiTechny Digital Camera for Kids" || !first.Sponsored {
+		t.Fatalf("❌ 第一个商品解析错误: %+v", first)
+	}
+	if first.Price == nil || *first.Price != 36.79 || first.Rating != 4.2 || first.ReviewCount != 1471 {
+		t.Fatalf("❌ 第一个商品价格/评分错误: %+v", first)
+	}
+	if first.ThumbnailURL == "" || first.LinkURL != AmazonProductDetailPrefixURL+"B0DFW4RR1H" {
+		t.Fatalf("❌ 第一个商品链接错误: %+v", first)
+	}
+
+	second := page.Products[1]
+	if second.Sponsored || second.Badge != "Best Seller" || second.Position != 2 {
+		t.Fatalf("❌ 第二个商品解析错误: %+v", second)
+	}
+	if second.Price == nil || *second.Price != 1036.50 || second.ReviewCount != 627 {
+		t.Fatalf("❌ 第二个商品价格/评论数错误: %+v", second)
+	}
+	if second.PriceMoney == nil || second.PriceMoney.Amount != 103650 || second.PriceMoney.Currency != "USD" {
+		t.Fatalf("❌ 第二个商品金额错误: %+v", second.PriceMoney)
+	}
+	t.Logf("✅ 解析到 %d 个商品，共 %d 页", len(page.Products), page.TotalPages)
+}
+
+// TestGetSearchPageDE 测试按德国站格式解析搜索结果的价格、评分和评论数（离线）
+func TestGetSearchPageDE(t *testing.T) {
+	html, err := os.ReadFile(filepath.Join("testdata", "search", "de_page.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := NewAmazonExtractor().GetSearchPage("https://www.amazon.de/s?k=kopfh%C3%B6rer", string(html))
+	if len(page.Products) != 2 || page.TotalPages != 7 || !page.HasNextPage {
+		t.Fatalf("❌ 搜索结果解析错误: %d 个商品, %d 页", len(page.Products), page.TotalPages)
+	}
+
+	first := page.Products[0]
+	if first.PriceMoney == nil || first.PriceMoney.Amount != 129999 || first.PriceMoney.Currency != "EUR" ||
+		first.Price == nil || *first.Price != 1299.99 {
+		t.Fatalf("❌ 价格应为 1299.99 EUR，实际: %v %+v", first.Price, first.PriceMoney)
+	}
+	if first.Rating != 4.5 || first.ReviewCount != 1234 || !first.Sponsored {
+		t.Fatalf("❌ 评分/评论数错误: %+v", first)
+	}
+	if first.LinkURL != "https://www.amazon.de/dp/B09XS7JWHH" {
+		t.Fatalf("❌ 商品链接应使用德国站: %s", first.LinkURL)
+	}
+
+	second := page.Products[1]
+	if second.PriceMoney == nil || second.PriceMoney.Amount != 3999 || second.Rating != 4.3 || second.ReviewCount != 12875 || second.Badge != "Bestseller" {
+		t.Fatalf("❌ 第二个商品解析错误: %+v", second)
+	}
+
+	// ⭐ 日本站评分文本以满分开头
+	if rating := parseRating("5つ星のうち4.2", MarketplaceJP); rating != 4.2 {
+		t.Fatalf("❌ 日本站评分应为4.2，实际 %v", rating)
+	}
+}
+
+// TestSearchProducts 测试关键词搜索商品
+func TestSearchProducts(t *testing.T) {
+	spider := NewAmazonSpider()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	result, err := spider.SearchProducts(ctx, "digital camera", SearchOptions{Page: 1, MaxPages: 2})
+	if err != nil {
+		t.Logf("❌ 关键词搜索失败: %v", err)
+		return
+	}
+
+	t.Logf("✅ 共抓取 %d 个商品，最后一页: %d/%d", len(result.Products), result.LastPage, result.TotalPages)
+	for _, product := range result.Products[:min(5, len(result.Products))] {
+		t.Logf("  📦 [%s] %s %s ⭐%.1f (%d) sponsored=%v", product.ASIN, truncateString(product.Title, 50), product.PriceText, product.Rating, product.ReviewCount, product.Sponsored)
+	}
+}
+
+// reviewPageHTML 评论页示例
+const reviewPageHTML = `<html><body>
+<div data-hook="review" id="R1ABCDEF">
+  <span class="a-profile-name">Jane Doe</span>
+  <a data-hook="review-title"><i data-hook="review-star-rating"><span class="a-icon-alt">5.0 out of 5 stars</span></i><span class="a-letter-space"></span><span>Great headphones</span></a>
+  <span data-hook="review-date">Reviewed in the United States on January 2, 2024</span>
+  <span data-hook="avp-badge">Verified Purchase</span>
+  <span data-hook="review-body"><span>Noise cancelling is excellent.</span></span>
+  <span data-hook="helpful-vote-statement">1,234 people found this helpful</span>
+  <img data-hook="review-image-tile" src="https://m.media-amazon.com/images/I/81review._SY88.jpg">
+  <div id="review-video-id-1" data-video-url="https://m.media-amazon.com/images/S/vse-vms/review.mp4"></div>
+</div>
+<div data-hook="review" id="R2GHIJKL">
+  <span class="a-profile-name">John Smith</span>
+  <span data-hook="review-title"><i data-hook="cmps-review-star-rating"><span class="a-icon-alt">2.0 out of 5 stars</span></i><span>Broke quickly</span></span>
+  <span data-hook="review-date">Reviewed in Canada on March 15, 2023</span>
+  <span data-hook="review-body">Stopped working after a month.</span>
+  <span data-hook="helpful-vote-statement">One person found this helpful</span>
+</div>
+<ul class="a-pagination"><li class="a-last"><a href="?pageNumber=2">Next page</a></li></ul>
+</body></html>`
+
+// TestGetReviewPage 测试解析评论页（离线）
+func TestGetReviewPage(t *testing.T) {
+	page := NewAmazonExtractor().GetReviewPage(reviewPageHTML)
+
+	if len(page.Reviews) != 2 || !page.HasNextPage {
+		t.Fatalf("❌ 期望2条评论且有下一页，实际 %d 条, next=%v", len(page.Reviews), page.HasNextPage)
+	}
+
+	first := page.Reviews[0]
+	if first.ID != "R1ABCDEF" || first.Author != "Jane Doe" || first.Title != "Great headphones" || first.Rating != 5 {
+		t.Fatalf("❌ 第一条评论解析错误: %+v", first)
+	}
+	if !first.Verified || first.Country != "United States" || first.Date == nil || first.Date.Format("2006-01-02") != "2024-01-02" {
+		t.Fatalf("❌ 第一条评论验证/日期错误: %+v", first)
+	}
+	if first.HelpfulVotes != 1234 || len(first.Images) != 1 || len(first.Videos) != 1 {
+		t.Fatalf("❌ 第一条评论投票/图片/视频错误: %+v", first)
+	}
+
+	second := page.Reviews[1]
+	if second.Verified || second.Rating != 2 || second.Country != "Canada" || second.HelpfulVotes != 1 || second.Body != "Stopped working after a month." {
+		t.Fatalf("❌ 第二条评论解析错误: %+v", second)
+	}
+	t.Logf("✅ 解析到 %d 条评论", len(page.Reviews))
+}
+
+// TestGetReviewPageDE 测试解析德国站评论页的评分、日期、国家和投票数（离线）
+func TestGetReviewPageDE(t *testing.T) {
+	html, err := os.ReadFile(filepath.Join("testdata", "reviews", "de_page.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := NewAmazonExtractor().GetReviewPage(string(html))
+	if len(page.Reviews) != 2 || page.HasNextPage {
+		t.Fatalf("❌ 期望2条评论且没有下一页，实际 %d 条, next=%v", len(page.Reviews), page.HasNextPage)
+	}
+
+	first := page.Reviews[0]
+	if first.Rating != 4 || first.Country != "Deutschland" || first.Date == nil || first.Date.Format("2006-01-02") != "2024-03-03" {
+		t.Fatalf("❌ 第一条评论评分/日期错误: %+v", first)
+	}
+	if !first.Verified || first.HelpfulVotes != 1234 || first.Title != "Sehr guter Klang" {
+		t.Fatalf("❌ 第一条评论解析错误: %+v", first)
+	}
+
+	second := page.Reviews[1]
+	if second.Rating != 2 || second.Country != "Vereinigten Königreich" || second.Date == nil || second.Date.Format("2006-01-02") != "2023-12-21" || second.HelpfulVotes != 1 {
+		t.Fatalf("❌ 第二条评论解析错误: %+v", second)
+	}
+}
+
+// TestParseReviewDate 测试各站点语言的评论日期行（离线）
+func TestParseReviewDate(t *testing.T) {
+	cases := []struct {
+		text, country, date string
+	}{
+		{"Reviewed in the United States on January 2, 2024", "United States", "2024-01-02"},
+		{"Reviewed in the United Kingdom on 14 February 2024", "United Kingdom", "2024-02-14"},
+		{"Rezension aus Deutschland vom 1. Mai 2024", "Deutschland", "2024-05-01"},
+		{"Commenté en France le 9 août 2023", "France", "2023-08-09"},
+		{"Commenté aux États-Unis le 17 décembre 2022", "États-Unis", "2022-12-17"},
+		{"Revisado en España el 5 de marzo de 2024", "España", "2024-03-05"},
+		{"Recensito in Italia il 30 settembre 2023", "Italia", "2023-09-30"},
+		{"2024年1月2日に日本でレビュー済み", "日本", "2024-01-02"},
+	}
+	for _, c := range cases {
+		country, date := parseReviewDate(c.text)
+		if country != c.country || date == nil || date.Format("2006-01-02") != c.date {
+			t.Errorf("❌ parseReviewDate(%q) = %q %v, 期望 %q %s", c.text, country, date, c.country, c.date)
+		}
+	}
+	if country, date := parseReviewDate("Bewertet am 2. Januar 2024"); country != "" || date != nil {
+		t.Errorf("❌ 无法识别的写法应返回空值: %q %v", country, date)
+	}
+}
+
+// TestFetchReviews 测试抓取商品评论
+func TestFetchReviews(t *testing.T) {
+	spider := NewAmazonSpider()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	result, err := spider.FetchReviews(ctx, "B09XS7JWHH", ReviewOptions{Star: ReviewStarCritical, SortBy: ReviewSortRecent, VerifiedOnly: true})
+	if err != nil {
+		t.Logf("❌ 抓取评论失败: %v", err)
+		return
+	}
+
+	t.Logf("✅ 抓取到 %d 条评论，有下一页: %v", len(result.Reviews), result.HasNextPage)
+	for _, review := range result.Reviews[:min(5, len(result.Reviews))] {
+		t.Logf("  💬 ⭐%.0f %s - %s (%s)", review.Rating, review.Author, truncateString(review.Title, 40), review.DateText)
+	}
+}
+
+const productPageHTML = `<html><body>
+<span id="productTitle"> Wireless Headphones </span>
+<div id="feature-bullets"><ul>
+<li><span class="a-list-item"> 40-hour battery life </span></li>
+<li><span class="a-list-item">Active noise
+    cancelling</span></li>
+</ul></div>
+<div class="aplus-v2 desktop celwidget">Designed for all-day comfort.</div>
+<div id="productDescription"><p>Over-ear headphones.</p></div>
+<table id="productDetails_techSpec_section_1">
+<tr><th> Brand </th><td>&#8206;Acme</td></tr>
+<tr><th>Color</th><td>Black</td></tr>
+</table>
+<div id="detailBullets_feature_div"><ul>
+<li><span class="a-list-item"><span class="a-text-bold">Brand &#8207; : &#8206;</span><span>Other</span></span></li>
+<li><span class="a-list-item"><span class="a-text-bold">ASIN &#8207; : &#8206;</span><span>B000000001</span></span></li>
+</ul></div>
+</body></html>`
+
+// TestGetProductDetailFields 测试产品详情的结构化字段（离线）
+func TestGetProductDetailFields(t *testing.T) {
+	result := NewAmazonExtractor().GetProductDetail("https://www.amazon.com/dp/B000000001", productPageHTML)
+
+	if len(result.FeatureBullets) != 2 || result.FeatureBullets[1] != "Active noise cancelling" {
+		t.Fatalf("❌ 五点描述解析错误: %q", result.FeatureBullets)
+	}
+	if result.APlusDesc != "Designed for all-day comfort." || result.ProductDesc != "Over-ear headphones." {
+		t.Fatalf("❌ 描述解析错误: aplus=%q desc=%q", result.APlusDesc, result.ProductDesc)
+	}
+
+	// 表格优先，detailBullets 中重复的 Brand 被忽略
+	want := []ProductSpec{{"Brand", "Acme"}, {"Color", "Black"}, {"ASIN", "B000000001"}}
+	if !reflect.DeepEqual(result.Specs, want) {
+		t.Fatalf("❌ 规格参数解析错误: %+v", result.Specs)
+	}
+	if result.Spec("asin") != "B000000001" || result.Spec("Weight") != "" {
+		t.Fatalf("❌ Spec 查找错误")
+	}
+	t.Logf("✅ 解析到 %d 条五点描述, %d 条规格参数", len(result.FeatureBullets), len(result.Specs))
+}
+
+// TestGetProductRanking 测试评分、畅销排名和面包屑（离线）
+func TestGetProductRanking(t *testing.T) {
+	html, err := os.ReadFile(filepath.Join("testdata", "product", "us_standard.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := NewAmazonExtractor().GetProductDetail("https://www.amazon.com/dp/B0DFW4RR1H", string(html))
+
+	if result.Rating == nil || result.Rating.Average != 4.4 || result.Rating.Count != 12845 ||
+		!reflect.DeepEqual(result.Rating.Histogram, map[int]int{5: 68, 4: 17, 3: 7, 2: 3, 1: 5}) {
+		t.Fatalf("❌ 评分解析错误: %+v", result.Rating)
+	}
+	if len(result.Breadcrumbs) != 4 || result.Breadcrumbs[3].NodeID != "12097479011" {
+		t.Fatalf("❌ 面包屑解析错误: %+v", result.Breadcrumbs)
+	}
+	if len(result.SalesRanks) != 3 {
+		t.Fatalf("❌ 应解析出3条排名: %+v", result.SalesRanks)
+	}
+	// 排名类目按类目ID在面包屑中查找路径（名称不同）
+	sub := result.SalesRanks[1]
+	if sub.Rank != 12 || sub.Category != "Earbud & In-Ear Headphones" || len(sub.CategoryPath) != 4 || sub.CategoryPath[0] != "Electronics" {
+		t.Fatalf("❌ 小类排名解析错误: %+v", sub)
+	}
+
+	// 旧版表格布局的星级分布，没有评分和排名的页面返回nil
+	table := `<table id="histogramTable"><tr><td>5 star</td><td>71%</td></tr><tr><td>1 star</td><td>4 %</td></tr></table>`
+	if rating := NewAmazonExtractor().GetProductDetail("https://www.amazon.de/dp/B000000001", table).Rating; rating == nil ||
+		!reflect.DeepEqual(rating.Histogram, map[int]int{5: 71, 1: 4}) {
+		t.Fatalf("❌ 表格布局星级分布解析错误: %+v", rating)
+	}
+	// ⭐ 日本站评分文本以满分开头（"5つ星のうち4.3"）
+	jp := `<span id="acrPopover"><span class="a-icon-alt">5つ星のうち4.3</span></span><span id="acrCustomerReviewText">1,234個の評価</span>`
+	if rating := NewAmazonExtractor().GetProductDetail("https://www.amazon.co.jp/dp/B000000001", jp).Rating; rating == nil ||
+		rating.Average != 4.3 || rating.Count != 1234 {
+		t.Fatalf("❌ 日本站评分解析错误: %+v", rating)
+	}
+	plain := NewAmazonExtractor().GetProductDetail("https://www.amazon.com/dp/B000000001", productPageHTML)
+	if plain.Rating != nil || plain.SalesRanks != nil || plain.Breadcrumbs != nil {
+		t.Fatalf("❌ 页面没有时应为nil: %+v %+v %+v", plain.Rating, plain.SalesRanks, plain.Breadcrumbs)
+	}
+}
+
+// updateGolden 重新生成 testdata 中的 golden 文件: go test -run TestProductPageGolden -update
+var updateGolden = flag.Bool("update", false, "重新生成 testdata 中的 golden 文件")
+
+// goldenURLPattern 固定页面第一行记录的商品链接（站点和ASIN由链接决定）
+var goldenURLPattern = regexp.MustCompile(`^<!-- url: (\S+) -->`)
+
+// TestProductPageGolden 用保存的商品详情页测试解析结果（离线）
+//
+// 📁 testdata/product/*.html 为保存的页面（不同布局、站点、有无A+、视频轮播、促销），
+// 同名的 .golden.json 为期望的 ProductResult；修改解析逻辑后加 -update 重新生成，并检查 git diff
+func TestProductPageGolden(t *testing.T) {
+	pages, err := filepath.Glob(filepath.Join("testdata", "product", "*.html"))
+	if err != nil || len(pages) == 0 {
+		t.Fatalf("❌ 没有找到固定页面: %v", err)
+	}
+
+	extractor := NewAmazonExtractor()
+	for _, page := range pages {
+		name := strings.TrimSuffix(filepath.Base(page), ".html")
+		t.Run(name, func(t *testing.T) {
+			html, err := os.ReadFile(page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			match := goldenURLPattern.FindSubmatch(html)
+			if match == nil {
+				t.Fatal("❌ 页面第一行缺少 <!-- url: ... --> 注释")
+			}
+
+			got, err := marshalGolden(extractor.GetProductDetail(string(match[1]), string(html)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			goldenPath := strings.TrimSuffix(page, ".html") + ".golden.json"
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("❌ 读取golden文件失败（新增页面请加 -update 生成）: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				for _, field := range diffGoldenFields(t, want, got) {
+					t.Errorf("❌ %s 与 %s 不一致\n期望: %s\n实际: %s", field.name, filepath.Base(goldenPath), field.want, field.got)
+				}
+				t.Fatalf("❌ 解析结果与 %s 不一致，确认改动符合预期后加 -update 重新生成", goldenPath)
+			}
+		})
+	}
+}
+
+// marshalGolden 序列化为 golden 文件格式（缩进、不转义HTML字符，便于阅读差异）
+func marshalGolden(result ProductResult) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// goldenFieldDiff 不一致的字段
+type goldenFieldDiff struct {
+	name      string
+	want, got string
+}
+
+// diffGoldenFields 逐个字段比较两个 ProductResult 的JSON
+func diffGoldenFields(t *testing.T, want, got []byte) []goldenFieldDiff {
+	t.Helper()
+	var wantFields, gotFields map[string]json.RawMessage
+	if err := json.Unmarshal(want, &wantFields); err != nil {
+		t.Fatalf("❌ golden文件不是有效的JSON: %v", err)
+	}
+	if err := json.Unmarshal(got, &gotFields); err != nil {
+		t.Fatalf("❌ 解析结果不是有效的JSON: %v", err)
+	}
+
+	var names []string
+	for name := range gotFields {
+		names = append(names, name)
+	}
+	for name := range wantFields {
+		if _, ok := gotFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []goldenFieldDiff
+	for _, name := range names {
+		if !bytes.Equal(compactJSON(wantFields[name]), compactJSON(gotFields[name])) {
+			diffs = append(diffs, goldenFieldDiff{name, string(compactJSON(wantFields[name])), string(compactJSON(gotFields[name]))})
+		}
+	}
+	return diffs
+}
+
+// compactJSON 去掉JSON中的缩进，字段缺失时返回 null
+func compactJSON(raw json.RawMessage) []byte {
+	var buf bytes.Buffer
+	if raw == nil || json.Compact(&buf, raw) != nil {
+		return []byte("null")
+	}
+	return buf.Bytes()
+}
+
+// TestRules 测试解析规则的加载、合并和校验（离线）
+func TestRules(t *testing.T) {
+	defaults := DefaultRules()
+	if defaults.Schema != RulesSchema || defaults.Version == "" || len(defaults.Product.Title.Selectors) == 0 {
+		t.Fatalf("❌ 内置规则无效: %+v", defaults)
+	}
+	defaults.Product.Title.Selectors[0] = "#changed"
+	if DefaultRules().Product.Title.Selectors[0] != "#productTitle" {
+		t.Fatal("❌ DefaultRules 应返回副本")
+	}
+
+	rules, err := ParseRules([]byte(`{"version": "custom-1", "product": {"title": {"selectors": ["#missing", "h1.title"]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules.Version != "custom-1" || len(rules.Product.Title.Selectors) != 2 ||
+		!reflect.DeepEqual(rules.Product.Price, DefaultRules().Product.Price) {
+		t.Fatalf("❌ 只应覆盖文件中的规则: %+v", rules.Product)
+	}
+
+	invalid := map[string]string{
+		"格式版本": `{"schema": 2}`,
+		"后处理":  `{"product": {"price": {"post": ["upper"]}}}`,
+		"正则":   `{"product": {"images": {"fallback_url": "("}}}`,
+		"JSON": `{"product": `,
+	}
+	for name, data := range invalid {
+		if _, err := ParseRules([]byte(data)); err == nil {
+			t.Errorf("❌ %s无效时应返回错误", name)
+		}
+	}
+	if _, err := LoadRulesFile(filepath.Join("testdata", "missing.json")); err == nil {
+		t.Error("❌ 规则文件不存在时应返回错误")
+	}
+}
+
+// TestSetRules 测试运行中替换解析规则（离线）
+func TestSetRules(t *testing.T) {
+	const productURL = "https://www.amazon.com/dp/B000000001"
+	page := `<html><body><h1 class="title"> New layout title </h1>
+<div id="price-block"><span class="price">Price: $12.50 today</span></div></body></html>`
+
+	extractor := NewAmazonExtractor()
+	if got := extractor.GetProductDetail(productURL, page); got.Title != "" || got.PriceMoney != nil {
+		t.Fatalf("❌ 内置规则不应识别新布局: %+v", got)
+	}
+
+	rules, err := ParseRules([]byte(`{"version": "test", "product": {
+		"title": {"selectors": ["#productTitle", "h1.title"]},
+		"price": {"selectors": ["#price-block .price"], "post": ["clean"], "pattern": "(\\$[\\d.]+)"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	extractor.SetRules(rules)
+	got := extractor.GetProductDetail(productURL, page)
+	if got.Title != "New layout title" || got.PriceMoney == nil || got.PriceMoney.Amount != 1250 {
+		t.Fatalf("❌ 新规则解析错误: title=%q price=%+v", got.Title, got.PriceMoney)
+	}
+
+	// 🔄 解析过程中替换规则（-race 检查）
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				extractor.GetProductDetail(productURL, productPageHTML)
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		extractor.SetRules(rules).SetRules(nil)
+	}
+	wg.Wait()
+
+	if extractor.Rules().Version != DefaultRules().Version {
+		t.Fatalf("❌ SetRules(nil) 应恢复内置规则: %s", extractor.Rules().Version)
+	}
+
+	// 🎬 评论视频规则同时用于商品详情页和评论页
+	videoRules, err := ParseRules([]byte(`{"product": {"videos": {"reviews": [{"selector": "video.review-clip", "attr": "src"}]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	extractor.SetRules(videoRules)
+	reviewPage := `<div data-hook="review" id="R1"><video class="review-clip" src="https://example.com/clip.mp4"></video>
+<div id="review-video-id-1" data-video-url="https://example.com/old.mp4"></div></div>`
+	if videos := extractor.GetReviewPage(reviewPage).Reviews[0].Videos; !reflect.DeepEqual(videos, []string{"https://example.com/clip.mp4"}) {
+		t.Fatalf("❌ 评论页应按规则解析视频: %v", videos)
+	}
+	if videos := extractor.GetProductDetail(productURL, reviewPage).Videos; !reflect.DeepEqual(videos, []string{"https://example.com/clip.mp4"}) {
+		t.Fatalf("❌ 商品详情页应按规则解析评论视频: %v", videos)
+	}
+}
+
+// TestMarketplace 测试站点查找、链接构建与 CountriesInfo 的对应关系（离线）
+func TestMarketplace(t *testing.T) {
+	for _, input := range []string{"JP", "jp", "amazon.co.jp", "www.amazon.co.jp", "https://www.amazon.co.jp/dp/B000000001?th=1"} {
+		if m, ok := LookupMarketplace(input); !ok || m.Code != "JP" {
+			t.Fatalf("❌ %q 应识别为日本站: %+v", input, m)
+		}
+	}
+	if m, ok := MarketplaceByCode("GB"); !ok || m != MarketplaceUK {
+		t.Fatal("❌ GB 应等同于 UK")
+	}
+	if _, ok := LookupMarketplace("amazon.example"); ok {
+		t.Fatal("❌ 未知域名不应识别为站点")
+	}
+
+	if got := MarketplaceDE.ProductURL("B000000001"); got != "https://www.amazon.de/dp/B000000001" {
+		t.Fatalf("❌ 商品链接错误: %s", got)
+	}
+	if MarketplaceUS.ProductURL("B000000001") != AmazonProductDetailPrefixURL+"B000000001" ||
+		MarketplaceUS.SearchURL() != AmazonSearchURL ||
+		MarketplaceUS.ShopLookURL() != AmazonShopLookURL ||
+		MarketplaceUS.StyleSnapUploadURL() != AmazonStyleSnapUploadURL {
+		t.Fatal("❌ 美国站链接应与原有常量一致")
+	}
+
+	// 每个预置站点都能在 CountriesInfo 中找到对应国家，商品链接能通过URL校验
+	for _, m := range Marketplaces() {
+		if m.CountryInfo().Country != m.Country {
+			t.Errorf("❌ %s 在 CountriesInfo 中找不到国家 %s", m.Code, m.Country)
+		}
+		if len(new(AmazonUtil).URLCheck([]string{m.ProductURL("B000000001")})) != 1 {
+			t.Errorf("❌ %s 商品链接未通过URL校验", m.Code)
+		}
+	}
+	if got := (Marketplace{Code: "XX", Country: "未知"}).CountryInfo(); got.Country != MarketplaceUS.Country {
+		t.Fatalf("❌ 未知国家应回退到美国，实际 %s", got.Country)
+	}
+	if MarketplaceUK.CurrencySymbol() != "£" || MarketplaceJP.LanguageCode() != "JP" {
+		t.Fatalf("❌ 货币/语言错误: %s %s", MarketplaceUK.CurrencySymbol(), MarketplaceJP.LanguageCode())
+	}
+}
+
+// recordingTransport 记录请求并返回固定页面，用于离线验证请求的站点和请求头
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	body     string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+// TestMarketplaceRequests 测试请求发往对应站点并带上站点语言（离线）
+func TestMarketplaceRequests(t *testing.T) {
+	transport := &recordingTransport{body: searchPageHTML}
+	spider := NewAmazonSpider().SetRetryCount(0).SetMarketplace(MarketplaceJP)
+	spider.client.SetTransport(transport)
+	ctx := context.Background()
+
+	result, err := spider.SearchProducts(ctx, "camera", SearchOptions{})
+	if err != nil {
+		t.Fatalf("❌ 搜索失败: %v", err)
+	}
+	if result.Products[0].LinkURL != "https://www.amazon.co.jp/dp/B0DFW4RR1H" {
+		t.Fatalf("❌ 商品链接应使用日本站域名: %s", result.Products[0].LinkURL)
+	}
+
+	// 单次调用指定的站点优先于默认站点
+	if _, err := spider.FetchReviews(ctx, "B0DFW4RR1H", ReviewOptions{Marketplace: MarketplaceDE}); err != nil {
+		t.Fatalf("❌ 抓取评论失败: %v", err)
+	}
+
+	// 商品详情按URL域名识别站点
+	transport.body = productPageHTML
+	product, err := spider.FetchProductDetail(ctx, "https://www.amazon.fr/dp/B000000001")
+	if err != nil {
+		t.Fatalf("❌ 获取商品详情失败: %v", err)
+	}
+	if product.Language != MarketplaceFR.LanguageCode() {
+		t.Fatalf("❌ 语言应取自法国站: %s", product.Language)
+	}
+
+	want := []struct{ host, lang string }{
+		{"www.amazon.co.jp", MarketplaceJP.AcceptLanguage},
+		{"www.amazon.de", MarketplaceDE.AcceptLanguage},
+		{"www.amazon.fr", MarketplaceFR.AcceptLanguage},
+	}
+	if len(transport.requests) != len(want) {
+		t.Fatalf("❌ 期望 %d 个请求，实际 %d", len(want), len(transport.requests))
+	}
+	for i, w := range want {
+		req := transport.requests[i]
+		if req.URL.Host != w.host || req.Header.Get("accept-language") != w.lang || req.Header.Get("referer") != "https://"+w.host {
+			t.Errorf("❌ 第%d个请求错误: %s accept-language=%q referer=%q", i+1, req.URL, req.Header.Get("accept-language"), req.Header.Get("referer"))
+		}
+	}
+}
+
+// TestParseMoney 测试按站点格式解析金额（离线）
+func TestParseMoney(t *testing.T) {
+	cases := []struct {
+		text     string
+		mp       Marketplace
+		amount   int64
+		currency string
+	}{
+		{"$1,299.99", MarketplaceUS, 129999, "USD"},
+		{"$36.79 with 20 percent savings", MarketplaceUS, 3679, "USD"},
+		{"1.299,99 €", MarketplaceDE, 129999, "EUR"},
+		{"1.299 €", MarketplaceDE, 129900, "EUR"},
+		{"1\u202f299,99\u00a0€", MarketplaceFR, 129999, "EUR"},
+		{"12,50 €", MarketplaceES, 1250, "EUR"},
+		{"£24.99", MarketplaceUK, 2499, "GBP"},
+		{"￥1,280", MarketplaceJP, 1280, "JPY"},
+		{"₹1,24,999.00", MarketplaceIN, 12499900, "INR"},
+		{"EUR 9,99", MarketplaceUS, 999, "EUR"},
+		{"29,99 €", MarketplaceUS, 2999, "EUR"}, // 站点未知时按格式推断
+	}
+	for _, c := range cases {
+		money, ok := ParseMoney(c.text, c.mp)
+		if !ok || money.Amount != c.amount || money.Currency != c.currency {
+			t.Errorf("❌ ParseMoney(%q, %s) = %+v, 期望 %d %s", c.text, c.mp.Code, money, c.amount, c.currency)
+		}
+	}
+	if _, ok := ParseMoney("Currently unavailable", MarketplaceUS); ok {
+		t.Error("❌ 没有数字的文本不应解析成功")
+	}
+	if money, ok := ParseMoney("$"+strings.Repeat("9", 30), MarketplaceUS); ok {
+		t.Errorf("❌ 超出 int64 范围的金额不应解析成功: %+v", money)
+	}
+	if money, _ := ParseMoney("1.299,99 €", MarketplaceDE); money.Float64() != 1299.99 || money.String() != "1299.99 EUR" {
+		t.Errorf("❌ 金额转换错误: %v %s", money.Float64(), money)
+	}
+}
+
+// TestParseDecimalMarketplaces 测试按各站点的小数点和千分位分隔符解析数字（离线）
+func TestParseDecimalMarketplaces(t *testing.T) {
+	for _, mp := range Marketplaces() {
+		d, g := mp.DecimalSeparator, mp.GroupingSeparator
+		cases := map[string]float64{
+			"1" + g + "234" + d + "56": 1234.56,
+			"1" + g + "234":            1234,
+			"12" + d + "5":             12.5,
+			"1" + d + "234":            1.234,
+		}
+		for text, want := range cases {
+			if got, ok := parseDecimal(text, mp); !ok || got != want {
+				t.Errorf("❌ %s站 parseDecimal(%q) = %v, 期望 %v", mp.Code, text, got, want)
+			}
+		}
+	}
+
+	// 🌍 同一文本在不同站点的解释不同
+	ambiguous := []struct {
+		text string
+		mp   Marketplace
+		want float64
+	}{
+		{"1.234", MarketplaceDE, 1234},
+		{"1.234", MarketplaceUS, 1.234},
+		{"1 234", MarketplaceFR, 1234},
+		{"1 234", MarketplaceUS, 1},
+		{"1,234", MarketplaceFR, 1.234},
+		{"1,234", MarketplaceUK, 1234},
+	}
+	for _, c := range ambiguous {
+		if got, _ := parseDecimal(c.text, c.mp); got != c.want {
+			t.Errorf("❌ %s站 parseDecimal(%q) = %v, 期望 %v", c.mp.Code, c.text, got, c.want)
+		}
+	}
+}
+
+// TestExtractPrice 测试提取价格时区分小数点与千分位（离线）
+func TestExtractPrice(t *testing.T) {
+	cases := map[string]float64{
+		"$1,036.50":          1036.50,
+		"1.299,99 €":         1299.99,
+		"4.2 out of 5 stars": 4.2,
+		"4,6 von 5 Sternen":  4.6,
+		"1,471":              1471,
+		"-15%":               15,
+	}
+	util := &AmazonUtil{}
+	for text, want := range cases {
+		if got := util.ExtractPrice(text); got == nil || *got != want {
+			t.Errorf("❌ ExtractPrice(%q) = %v, 期望 %v", text, got, want)
+		}
+	}
+}
+
+// TestGetProductDetailPrice 测试商品详情按站点解析价格（离线）
+func TestGetProductDetailPrice(t *testing.T) {
+	html := `<html><body><span id="productTitle">Kopfhörer</span>
+<span class="aok-offscreen"> 1.299,99 € mit 15 Prozent Einsparungen </span></body></html>`
+
+	result := NewAmazonExtractor().GetProductDetail("https://www.amazon.de/dp/B000000001", html)
+	if result.PriceMoney == nil || result.PriceMoney.Amount != 129999 || result.PriceMoney.Currency != "EUR" {
+		t.Fatalf("❌ 价格解析错误: %+v", result.PriceMoney)
+	}
+	if result.Price == nil || *result.Price != "€1299.99" || result.Language != "DE" {
+		t.Fatalf("❌ 价格文本/语言错误: %v %s", result.Price, result.Language)
+	}
+}
+
+// TestExtractASIN 测试从各种链接形式中提取ASIN和规范化链接（离线）
+func TestExtractASIN(t *testing.T) {
+	cases := []struct {
+		input     string
+		asin      string
+		canonical string
+	}{
+		{"B0DFW4RR1H", "B0DFW4RR1H", ""},
+		{"https://www.amazon.com/AiTechny-Digital-Camera/dp/B0DFW4RR1H/ref=sr_1_1?th=1", "B0DFW4RR1H", "https://www.amazon.com/dp/B0DFW4RR1H"},
+		{"https://amazon.de/gp/product/B0DFW4RR1H?psc=1", "B0DFW4RR1H", "https://www.amazon.de/dp/B0DFW4RR1H"},
+		{"https://www.amazon.co.jp/gp/aw/d/b0dfw4rr1h", "B0DFW4RR1H", "https://www.amazon.co.jp/dp/B0DFW4RR1H"},
+		{"https://www.amazon.com/product-reviews/B0DFW4RR1H/", "B0DFW4RR1H", "https://www.amazon.com/dp/B0DFW4RR1H"},
+		{"https://www.amazon.ca/dp/B0DFW4RR1H", "B0DFW4RR1H", "https://www.amazon.ca/dp/B0DFW4RR1H"},
+		{"https://example.com/dp/B0DFW4RR1H", "B0DFW4RR1H", ""},
+		{"https://www.amazon.com/dp/B0DFW4RR1HX", "", ""},
+		{"https://amzn.to/3xYzAbC", "", ""},
+	}
+	for _, c := range cases {
+		asin, _ := ExtractASIN(c.input)
+		canonical, _ := CanonicalURL(c.input)
+		if asin != c.asin || canonical != c.canonical {
+			t.Errorf("❌ %s: ASIN=%q 规范链接=%q，期望 %q %q", c.input, asin, canonical, c.asin, c.canonical)
+		}
+	}
+	if IsValidASIN("b0dfw4rr1h") || !IsValidASIN("B0DFW4RR1H") {
+		t.Error("❌ ASIN校验错误")
+	}
+}
+
+// redirectTransport 模拟短链接跳转：短链接返回301，其他地址返回商品页
+type redirectTransport struct {
+	location string
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(productPageHTML)),
+		Request:    req,
+	}
+	if req.URL.Host == "amzn.to" {
+		resp.StatusCode = http.StatusMovedPermanently
+		resp.Header.Set("Location", t.location)
+		resp.Body = io.NopCloser(strings.NewReader(""))
+	}
+	return resp, nil
+}
+
+// TestResolveShortLink 测试短链接跳转后按规范链接获取商品详情（离线）
+func TestResolveShortLink(t *testing.T) {
+	transport := &redirectTransport{location: "https://www.amazon.co.uk/Wireless-Headphones/dp/B000000001?ref=share"}
+	spider := NewAmazonSpider().SetRetryCount(0)
+	spider.client.SetTransport(transport)
+	ctx := context.Background()
+
+	product, err := spider.FetchProductDetail(ctx, "https://amzn.to/3xYzAbC")
+	if err != nil {
+		t.Fatalf("❌ 获取商品详情失败: %v", err)
+	}
+	if product.LinkURL != "https://www.amazon.co.uk/dp/B000000001" || product.ASIN != "B000000001" {
+		t.Fatalf("❌ 规范链接错误: %s %s", product.LinkURL, product.ASIN)
+	}
+
+	// 短链接未指向商品页
+	transport.location = "https://www.amazon.co.uk/deals"
+	if _, err := spider.ResolveProductURL(ctx, "https://amzn.to/3xYzAbC"); !errors.Is(err, ErrNotProductPage) {
+		t.Fatalf("❌ 期望 ErrNotProductPage，实际 %v", err)
+	}
+
+	product, err = spider.FetchProductByASIN(ctx, MarketplaceJP, "b000000001")
+	if err != nil || product.LinkURL != "https://www.amazon.co.jp/dp/B000000001" {
+		t.Fatalf("❌ 按ASIN获取商品失败: %v %s", err, product.LinkURL)
+	}
+	if _, err := spider.FetchProductByASIN(ctx, MarketplaceJP, "B0001"); err == nil {
+		t.Fatal("❌ 无效ASIN应返回错误")
+	}
+}
+
+// concurrencyTransport 记录每个站点的最大并发数，延迟返回商品页
+type concurrencyTransport struct {
+	delay time.Duration
+
+	mu      sync.Mutex
+	active  map[string]int
+	maxSeen map[string]int
+	total   int
+	maxAll  int
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	t.mu.Lock()
+	t.active[host]++
+	t.total++
+	t.maxSeen[host] = max(t.maxSeen[host], t.active[host])
+	t.maxAll = max(t.maxAll, t.total)
+	t.mu.Unlock()
+
+	time.Sleep(t.delay)
+
+	t.mu.Lock()
+	t.active[host]--
+	t.total--
+	t.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(productPageHTML)),
+		Request:    req,
+	}, nil
+}
+
+// TestFetchProductDetails 测试批量获取的并发限制、顺序和逐项错误（离线，建议配合 -race 运行）
+func TestFetchProductDetails(t *testing.T) {
+	transport := &concurrencyTransport{delay: 20 * time.Millisecond, active: map[string]int{}, maxSeen: map[string]int{}}
+	spider := NewAmazonSpider().SetRetryCount(0)
+	spider.client.SetTransport(transport)
+
+	var urls []string
+	for i := 0; i < 6; i++ {
+		urls = append(urls, fmt.Sprintf("https://www.amazon.com/dp/B00000000%d", i), fmt.Sprintf("https://www.amazon.de/dp/B00000000%d", i))
+	}
+	urls = append(urls, "https://example.com/not-amazon")
+
+	var order []int
+	products, failures := spider.FetchProductDetails(context.Background(), urls, BatchOptions{
+		Concurrency:        4,
+		PerHostConcurrency: 2,
+		Ordered:            true,
+		OnResult:           func(r BatchResult) { order = append(order, r.Index) },
+	})
+
+	for i := range urls[:12] {
+		if failures[i] != nil || products[i].ASIN != fmt.Sprintf("B00000000%d", i/2) {
+			t.Fatalf("❌ 第%d个商品错误: %v %s", i, failures[i], products[i].ASIN)
+		}
+	}
+	if failures[12] == nil {
+		t.Fatal("❌ 无效URL应在对应位置返回错误")
+	}
+	for i, index := range order {
+		if index != i {
+			t.Fatalf("❌ 有序模式应按输入顺序回调: %v", order)
+		}
+	}
+	if transport.maxSeen["www.amazon.com"] > 2 || transport.maxSeen["www.amazon.de"] > 2 || transport.maxAll > 4 {
+		t.Fatalf("❌ 超出并发限制: %v 总计 %d", transport.maxSeen, transport.maxAll)
+	}
+	t.Logf("✅ 站点最大并发 %v，总最大并发 %d", transport.maxSeen, transport.maxAll)
+}
+
+// TestStreamProductDetails 测试流式输出、站点请求间隔和取消（离线）
+func TestStreamProductDetails(t *testing.T) {
+	transport := &concurrencyTransport{active: map[string]int{}, maxSeen: map[string]int{}}
+	spider := NewAmazonSpider().SetRetryCount(0)
+	spider.client.SetTransport(transport)
+
+	urls := []string{
+		"https://www.amazon.com/dp/B000000001",
+		"https://www.amazon.com/dp/B000000002",
+		"https://www.amazon.com/dp/B000000003",
+	}
+	start := time.Now()
+	count := 0
+	for result := range spider.StreamProductDetails(context.Background(), urls, BatchOptions{PerHostInterval: 30 * time.Millisecond}) {
+		if result.Err != nil || result.URL != urls[result.Index] {
+			t.Fatalf("❌ 结果错误: %+v", result)
+		}
+		count++
+	}
+	if count != len(urls) || time.Since(start) < 60*time.Millisecond {
+		t.Fatalf("❌ 期望 %d 个结果且按间隔发出请求，实际 %d 个，耗时 %v", len(urls), count, time.Since(start))
+	}
+
+	// 取消后channel关闭，未开始的商品不再请求
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for result := range spider.StreamProductDetails(ctx, urls, BatchOptions{}) {
+		t.Fatalf("❌ 取消后不应输出结果: %+v", result)
+	}
+	_, failures := spider.FetchProductDetails(ctx, urls, BatchOptions{})
+	for _, err := range failures {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("❌ 取消后应返回 context.Canceled，实际 %v", err)
+		}
+	}
+}
+
 // displayProducts 格式化显示商品信息
 func displayProducts(products []ImageSearchProduct, maxCount int) {
 	count := len(products)
@@ -248,3 +1368,376 @@ func readLocalImageFile(filePath string) ([]byte, error) {
 
 	return io.ReadAll(file)
 }
+
+// scriptedTransport 按路径依次返回预设的响应，最后一个响应重复使用
+type scriptedTransport struct {
+	mu        sync.Mutex
+	responses map[string][]scriptedResponse
+	hits      map[string]int
+}
+
+// scriptedResponse 预设的响应
+type scriptedResponse struct {
+	status int
+	body   string
+}
+
+func (t *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	responses := t.responses[req.URL.Path]
+	n := t.hits[req.URL.Path]
+	t.hits[req.URL.Path]++
+	t.mu.Unlock()
+
+	if len(responses) == 0 {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	}
+	resp := responses[min(n, len(responses)-1)]
+	return &http.Response{
+		StatusCode: resp.status,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(resp.body)),
+		Request:    req,
+	}, nil
+}
+
+// TestRetryPolicy 测试临时错误和stylesnap令牌失效时按重试策略重试（离线）
+func TestRetryPolicy(t *testing.T) {
+	transport := &scriptedTransport{hits: map[string]int{}, responses: map[string][]scriptedResponse{
+		"/dp/B000000001":    {{http.StatusServiceUnavailable, ""}, {http.StatusOK, productPageHTML}},
+		"/dp/B000000002":    {{http.StatusNotFound, ""}},
+		"/shopthelook":      {{http.StatusOK, `<html><body><input name="stylesnap" value="token"></body></html>`}},
+		"/stylesnap/upload": {{http.StatusForbidden, ""}, {http.StatusOK, `{"searchResults":[{"bbxAsinMetadataList":[{"asin":"B000000003","price":"$9.99"}]}]}`}},
+	}}
+	policy := retry.Default()
+	policy.InitialInterval = time.Millisecond
+	spider := NewAmazonSpider().SetRetryPolicy(policy)
+	spider.client.SetTransport(transport)
+	ctx := context.Background()
+
+	// 🔁 503 后重试成功
+	if result, err := spider.FetchProductDetail(ctx, "https://www.amazon.com/dp/B000000001"); err != nil || result.Title == "" {
+		t.Fatalf("❌ 503后应重试成功: %v", err)
+	}
+	// 🚫 404 不重试
+	if _, err := spider.FetchProductDetail(ctx, "https://www.amazon.com/dp/B000000002"); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("❌ 期望404错误，实际 %v", err)
+	}
+	// 🔑 令牌被拒绝后重新获取令牌再上传
+	products, err := spider.SearchProductsByImageData(ctx, []byte("img"), nil)
+	if err != nil || len(products) != 1 || products[0].ASIN != "B000000003" {
+		t.Fatalf("❌ 令牌失效后应重试成功: %v %+v", err, products)
+	}
+
+	want := map[string]int{"/dp/B000000001": 2, "/dp/B000000002": 1, "/shopthelook": 2, "/stylesnap/upload": 2}
+	if !reflect.DeepEqual(transport.hits, want) {
+		t.Fatalf("❌ 请求次数错误: %v，期望 %v", transport.hits, want)
+	}
+
+	// 🔢 SetRetryCount(0) 不重试，返回令牌失效错误
+	transport.hits = map[string]int{}
+	transport.responses["/stylesnap/upload"] = []scriptedResponse{{http.StatusForbidden, ""}}
+	if _, err := spider.SetRetryCount(0).SearchProductsByImageData(ctx, []byte("img"), nil); !errors.Is(err, ErrStylesnapExpired) || !errors.Is(err, errs.ErrBlocked) {
+		t.Fatalf("❌ 期望属于 errs.ErrBlocked 的 ErrStylesnapExpired，实际 %v", err)
+	}
+	if transport.hits["/stylesnap/upload"] != 1 {
+		t.Fatalf("❌ 不应重试: %v", transport.hits)
+	}
+}
+
+// TestFingerprints 测试请求头由浏览器指纹生成，同一会话使用同一个指纹（离线）
+func TestFingerprints(t *testing.T) {
+	transport := &recordingTransport{body: productPageHTML}
+	spider := NewAmazonSpider().SetRetryCount(0)
+	spider.client.SetTransport(transport)
+	productURL := "https://www.amazon.de/dp/B000000001"
+
+	// 🎭 默认指纹
+	if _, err := spider.FetchProductDetail(context.Background(), productURL); err != nil {
+		t.Fatalf("❌ 获取商品详情失败: %v", err)
+	}
+	req := transport.requests[0]
+	if req.Header.Get("user-agent") != fingerprint.DefaultProfile.UserAgent || req.Header.Get("sec-ch-ua") != fingerprint.DefaultProfile.SecCHUA {
+		t.Fatalf("❌ 应使用默认指纹: %v", req.Header)
+	}
+	if req.Header.Get("accept-language") != MarketplaceDE.AcceptLanguage || req.Header.Get("accept") != "*/*" {
+		t.Fatalf("❌ 站点和平台请求头错误: %v", req.Header)
+	}
+
+	// 🦊 非Chromium指纹不带 sec-ch-ua，同一会话的请求使用同一个指纹
+	spider.SetFingerprints(fingerprint.NewSelector(fingerprint.ProfilesByBrowser(fingerprint.Firefox)...))
+	transport.requests = nil
+	ctx := proxy.WithSession(context.Background(), "account-1")
+	for i := 0; i < 3; i++ {
+		if _, err := spider.FetchProductDetail(ctx, productURL); err != nil {
+			t.Fatalf("❌ 获取商品详情失败: %v", err)
+		}
+	}
+	userAgent := transport.requests[0].Header.Get("user-agent")
+	for _, req := range transport.requests {
+		if req.Header.Get("user-agent") != userAgent || !strings.Contains(userAgent, "Firefox/") {
+			t.Fatalf("❌ 同一会话应使用同一个Firefox指纹: %s", req.Header.Get("user-agent"))
+		}
+		for key := range req.Header {
+			if strings.HasPrefix(strings.ToLower(key), "sec-ch-ua") {
+				t.Fatalf("❌ Firefox 指纹不应发送 %s", key)
+			}
+		}
+	}
+}
+
+// TestGetVariations 测试解析变体矩阵（离线）
+func TestGetVariations(t *testing.T) {
+	html, err := os.ReadFile(filepath.Join("testdata", "product", "us_twister.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := NewAmazonExtractor().GetVariations("https://www.amazon.com/dp/B0TWIST001", string(html))
+
+	if result.ParentASIN != "B0TWISTPAR" || result.CurrentASIN != "B0TWIST001" {
+		t.Fatalf("❌ 父/当前ASIN错误: %s %s", result.ParentASIN, result.CurrentASIN)
+	}
+	wantDimensions := []VariationDimension{
+		{Key: "size_name", Name: "Size", Values: []string{"S", "M", "L"}},
+		{Key: "color_name", Name: "Color", Values: []string{"Black", "Navy"}},
+	}
+	if !reflect.DeepEqual(result.Dimensions, wantDimensions) {
+		t.Fatalf("❌ 维度解析错误: %+v", result.Dimensions)
+	}
+
+	var asins []string
+	for _, v := range result.Variations {
+		asins = append(asins, v.ASIN)
+	}
+	if !reflect.DeepEqual(asins, []string{"B0TWIST001", "B0TWIST004", "B0TWIST002", "B0TWIST005", "B0TWIST003"}) {
+		t.Fatalf("❌ 子ASIN应按维度取值排序: %v", asins)
+	}
+
+	byASIN := map[string]Variation{}
+	for _, v := range result.Variations {
+		byASIN[v.ASIN] = v
+	}
+	black := byASIN["B0TWIST001"]
+	if black.Values["size_name"] != "S" || black.Values["color_name"] != "Black" || black.LinkURL != "https://www.amazon.com/dp/B0TWIST001" {
+		t.Fatalf("❌ 取值或链接错误: %+v", black)
+	}
+	if black.Price == nil || black.Price.Amount != 1999 || black.Available == nil || !*black.Available ||
+		black.ImageURL != "https://m.media-amazon.com/images/I/41black._SS36_.jpg" {
+		t.Fatalf("❌ 变体选项信息错误: %+v", black)
+	}
+	// data-defaultasin 为空时从 data-dp-url 读取ASIN
+	if navy := byASIN["B0TWIST004"]; navy.Price == nil || navy.Price.Amount != 2150 {
+		t.Fatalf("❌ 应从商品链接属性读取ASIN: %+v", navy)
+	}
+	if large := byASIN["B0TWIST003"]; large.Available == nil || *large.Available {
+		t.Fatalf("❌ 无货的选项应为 Available=false: %+v", large)
+	}
+	// 没有对应选项时按颜色取 colorImages 中的图片
+	if mNavy := byASIN["B0TWIST005"]; mNavy.Price != nil || mNavy.Available != nil || mNavy.ImageURL != "https://m.media-amazon.com/images/I/41navy._AC_.jpg" {
+		t.Fatalf("❌ 缺少选项时的信息错误: %+v", mNavy)
+	}
+
+	if empty := NewAmazonExtractor().GetVariations("https://www.amazon.com/dp/B000000001", productPageHTML); len(empty.Variations) != 0 {
+		t.Fatalf("❌ 没有变体的商品不应返回子ASIN: %+v", empty)
+	}
+}
+
+// TestFetchVariations 测试获取变体及每个子ASIN的详情（离线）
+func TestFetchVariations(t *testing.T) {
+	html, err := os.ReadFile(filepath.Join("testdata", "product", "us_twister.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := os.ReadFile(filepath.Join("testdata", "product", "us_standard.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &scriptedTransport{hits: map[string]int{}, responses: map[string][]scriptedResponse{
+		"/dp/B0TWIST001": {{http.StatusOK, string(html)}},
+		"/dp/B0TWIST002": {{http.StatusOK, string(child)}},
+		"/dp/B0TWIST004": {{http.StatusOK, string(child)}},
+		"/dp/B0TWIST005": {{http.StatusOK, string(child)}},
+	}}
+	spider := NewAmazonSpider().SetRetryCount(0)
+	spider.client.SetTransport(transport)
+	ctx := context.Background()
+
+	result, err := spider.FetchVariations(ctx, "b0twist001", VariationOptions{})
+	if err != nil || len(result.Variations) != 5 {
+		t.Fatalf("❌ 获取变体失败: %v %+v", err, result)
+	}
+	if result.Variations[0].Detail != nil || transport.hits["/dp/B0TWIST002"] != 0 {
+		t.Fatal("❌ 未设置 FetchDetails 时不应获取子商品详情")
+	}
+
+	result, err = spider.FetchVariations(ctx, "B0TWIST001", VariationOptions{FetchDetails: true, Batch: BatchOptions{Concurrency: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range result.Variations {
+		switch v.ASIN {
+		case "B0TWIST003":
+			if v.Err == nil || v.Detail != nil {
+				t.Fatalf("❌ 子商品失败时应保存错误: %v", v.Err)
+			}
+		case "B0TWIST005":
+			if v.Err != nil || v.Detail == nil || v.Price == nil || v.Price.Amount != 3679 {
+				t.Fatalf("❌ 缺少价格时应使用详情页价格: %+v", v)
+			}
+		default:
+			if v.Err != nil || v.Detail == nil || v.Detail.Title == "" {
+				t.Fatalf("❌ %s 应获取到详情: %v", v.ASIN, v.Err)
+			}
+		}
+	}
+
+	if _, err := spider.FetchVariations(ctx, "invalid", VariationOptions{}); err == nil {
+		t.Fatal("❌ 无效的ASIN应返回错误")
+	}
+}
+
+// readOfferPage 读取 testdata/offers 中的报价页
+func readOfferPage(t *testing.T, name string) string {
+	t.Helper()
+	html, err := os.ReadFile(filepath.Join("testdata", "offers", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(html)
+}
+
+// TestGetOfferPage 测试解析报价列表页（离线）
+func TestGetOfferPage(t *testing.T) {
+	extractor := NewAmazonExtractor()
+	page := extractor.GetOfferPage("https://www.amazon.com/gp/aod/ajax", readOfferPage(t, "us_page1.html"))
+
+	if page.TotalOffers != 4 || len(page.Offers) != 2 {
+		t.Fatalf("❌ 报价数量错误: total=%d offers=%d", page.TotalOffers, len(page.Offers))
+	}
+	buyBox := page.BuyBox
+	if buyBox == nil || !buyBox.BuyBoxWinner {
+		t.Fatal("❌ 应解析出购物车报价")
+	}
+	wantBuyBox := Offer{
+		SellerName: "SoundPeats Official", SellerID: "A2SPDEALS77QX", Condition: "New",
+		Price:            &Money{Amount: 3679, Currency: "USD", Text: "$36.79"},
+		Shipping:         &Money{Amount: 0, Currency: "USD", Text: "FREE"},
+		ShipsFrom:        "Amazon.com",
+		DeliveryEstimate: "Tuesday, October 21",
+		Prime:            true, FulfilledByAmazon: true, BuyBoxWinner: true,
+	}
+	if !reflect.DeepEqual(*buyBox, wantBuyBox) {
+		t.Fatalf("❌ 购物车报价解析错误:\n got: %+v\nwant: %+v", *buyBox, wantBuyBox)
+	}
+
+	used := page.Offers[1]
+	if used.Condition != "Used - Like New" || used.SellerID != "A1GADGET0UT7" || used.Price == nil || used.Price.Amount != 2950 {
+		t.Fatalf("❌ 二手报价解析错误: %+v", used)
+	}
+	if used.Shipping == nil || used.Shipping.Amount != 599 || used.FulfilledByAmazon || used.Prime || used.BuyBoxWinner {
+		t.Fatalf("❌ 卖家自发货报价的运费或标记错误: %+v", used)
+	}
+
+	// 后续页只有报价元素，没有卖家链接时卖家ID为空
+	next := extractor.GetOfferPage("https://www.amazon.com/gp/aod/ajax", readOfferPage(t, "us_page2.html"))
+	if next.BuyBox != nil || len(next.Offers) != 1 {
+		t.Fatalf("❌ 第2页解析错误: %+v", next)
+	}
+	if resale := next.Offers[0]; resale.SellerName != "Amazon Resale" || resale.SellerID != "" || !resale.SoldByAmazon || !resale.FulfilledByAmazon {
+		t.Fatalf("❌ 亚马逊自营报价解析错误: %+v", resale)
+	}
+
+	// 德国站：没有购物车，价格和运费按站点格式解析
+	de := extractor.GetOfferPage("https://www.amazon.de/gp/aod/ajax", readOfferPage(t, "de_no_buybox.html"))
+	if de.BuyBox != nil || len(de.Offers) != 1 {
+		t.Fatalf("❌ 没有购物车时 BuyBox 应为nil: %+v", de)
+	}
+	if offer := de.Offers[0]; offer.Price == nil || offer.Price.Amount != 129999 || offer.Price.Currency != "EUR" ||
+		offer.Shipping == nil || offer.Shipping.Amount != 0 || offer.Shipping.Currency != "EUR" || !offer.Prime {
+		t.Fatalf("❌ 德国站报价解析错误: %+v", offer)
+	}
+}
+
+// TestIsAmazonSeller 测试按卖家ID和完整名称识别亚马逊自营（离线）
+func TestIsAmazonSeller(t *testing.T) {
+	cases := []struct {
+		name, sellerID string
+		mp             Marketplace
+		want           bool
+	}{
+		{"Amazon.com", "ATVPDKIKX0DER", MarketplaceUS, true},
+		{"Amazon.de", "A3JWKAKR8XB7XF", MarketplaceDE, true},
+		{"Amazon", "", MarketplaceDE, true},
+		{"Amazon.co.jp", "", MarketplaceJP, true},
+		{"Amazon Resale", "", MarketplaceUS, true},
+		{"AmazonBasics Outlet Store", "", MarketplaceUS, false},
+		{"Amazonas Trading", "A2AMAZONAS01", MarketplaceUS, false},
+		{"Amazon.com", "A3JWKAKR8XB7XF", MarketplaceUS, false}, // 其他站点的自营ID
+	}
+	for _, c := range cases {
+		if got := isAmazonSeller(c.name, c.sellerID, c.mp); got != c.want {
+			t.Errorf("❌ isAmazonSeller(%q, %q, %s) = %v, 期望 %v", c.name, c.sellerID, c.mp.Code, got, c.want)
+		}
+	}
+}
+
+// TestFetchOffers 测试报价列表翻页（离线）
+func TestFetchOffers(t *testing.T) {
+	transport := &scriptedTransport{hits: map[string]int{}, responses: map[string][]scriptedResponse{
+		"/gp/aod/ajax": {
+			{http.StatusOK, readOfferPage(t, "us_page1.html")},
+			{http.StatusOK, readOfferPage(t, "us_page2.html")},
+		},
+	}}
+	spider := NewAmazonSpider().SetRetryCount(0)
+	spider.client.SetTransport(transport)
+	ctx := context.Background()
+
+	result, err := spider.FetchOffers(ctx, "b0dfw4rr1h", OfferOptions{MaxPages: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ASIN != "B0DFW4RR1H" || result.TotalOffers != 4 || result.LastPage != 2 || result.HasNextPage {
+		t.Fatalf("❌ 分页信息错误: %+v", result)
+	}
+	if len(result.Offers) != 4 || result.BuyBox == nil || !result.Offers[0].BuyBoxWinner || result.Offers[0].SellerID != result.BuyBox.SellerID {
+		t.Fatalf("❌ 购物车报价应排在最前: %+v", result.Offers)
+	}
+	if transport.hits["/gp/aod/ajax"] != 2 {
+		t.Fatalf("❌ 取完所有报价后不应继续翻页: %d", transport.hits["/gp/aod/ajax"])
+	}
+
+	// 📄 报价总数包含购物车报价：购物车 + 2个报价共3个时只请求1页
+	onePage := strings.Replace(readOfferPage(t, "us_page1.html"), `value="4"`, `value="3"`, 1)
+	transport.hits["/gp/aod/ajax"] = 0
+	transport.responses["/gp/aod/ajax"] = []scriptedResponse{{http.StatusOK, onePage}, {http.StatusOK, readOfferPage(t, "us_page2.html")}}
+	result, err = spider.FetchOffers(ctx, "B0DFW4RR1H", OfferOptions{MaxPages: 5})
+	if err != nil || len(result.Offers) != 3 || result.LastPage != 1 || result.HasNextPage {
+		t.Fatalf("❌ 单页报价结果错误: %+v, %v", result, err)
+	}
+	if hits := transport.hits["/gp/aod/ajax"]; hits != 1 {
+		t.Fatalf("❌ 报价已取完时应只请求1次，实际 %d 次", hits)
+	}
+
+	// 按站点请求，商品页作为 referer
+	recording := &recordingTransport{body: readOfferPage(t, "de_no_buybox.html")}
+	spider.client.SetTransport(recording)
+	if _, err := spider.FetchOffers(ctx, "B0CHX3QBCH", OfferOptions{Marketplace: MarketplaceDE}); err != nil {
+		t.Fatal(err)
+	}
+	req := recording.requests[0]
+	if req.URL.Host != "www.amazon.de" || req.URL.Path != "/gp/aod/ajax" || req.URL.Query().Get("asin") != "B0CHX3QBCH" ||
+		req.URL.Query().Get("pageno") != "1" || req.Header.Get("referer") != "https://www.amazon.de/dp/B0CHX3QBCH" {
+		t.Fatalf("❌ 请求地址或请求头错误: %s referer=%q", req.URL, req.Header.Get("referer"))
+	}
+
+	spider.client.SetTransport(transport)
+
+	transport.responses["/gp/aod/ajax"] = []scriptedResponse{{http.StatusServiceUnavailable, ""}}
+	if _, err := spider.FetchOffers(ctx, "B0DFW4RR1H", OfferOptions{}); err == nil {
+		t.Fatal("❌ 请求失败时应返回错误")
+	}
+	if _, err := spider.FetchOffers(ctx, "invalid", OfferOptions{}); err == nil {
+		t.Fatal("❌ 无效的ASIN应返回错误")
+	}
+}
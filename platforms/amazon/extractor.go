@@ -6,20 +6,39 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
 // AmazonExtractor Amazon数据提取器
 type AmazonExtractor struct {
-	util *AmazonUtil
+	util  *AmazonUtil
+	rules atomic.Pointer[Rules] // 商品详情页解析规则，可在运行中替换
 }
 
-// NewAmazonExtractor 创建新的Amazon提取器
+// NewAmazonExtractor 创建新的Amazon提取器，使用内置默认规则
 func NewAmazonExtractor() *AmazonExtractor {
-	return &AmazonExtractor{
+	e := &AmazonExtractor{
 		util: &AmazonUtil{},
 	}
+	e.rules.Store(defaultRules)
+	return e
+}
+
+// SetRules 替换解析规则，nil 表示恢复内置默认规则
+// 🔄 可在抓取过程中随时调用，正在解析的页面继续使用旧规则
+func (e *AmazonExtractor) SetRules(rules *Rules) *AmazonExtractor {
+	if rules == nil {
+		rules = defaultRules
+	}
+	e.rules.Store(rules)
+	return e
+}
+
+// Rules 返回当前使用的解析规则
+func (e *AmazonExtractor) Rules() *Rules {
+	return e.rules.Load()
 }
 
 // getInnerText 获取HTML元素的文本内容 (私有方法)
@@ -28,40 +47,96 @@ func (e *AmazonExtractor) getInnerText(doc *goquery.Document, selectorText strin
 	return strings.TrimSpace(strings.ReplaceAll(text, "\t", " "))
 }
 
-// getProductTextDetail 获取产品详细信息 (私有方法)
-func (e *AmazonExtractor) getProductTextDetail(doc *goquery.Document) ProductDetail {
-	productTitle := e.getInnerText(doc, "#productTitle")
+// getRuleText 按规则获取文本，使用第一个结果非空的选择器 (私有方法)
+func (e *AmazonExtractor) getRuleText(doc *goquery.Document, rule TextRule) string {
+	return e.getRuleTextIn(doc.Selection, rule)
+}
+
+// getRuleTextIn 在指定元素内按规则获取文本 (私有方法)
+func (e *AmazonExtractor) getRuleTextIn(sel *goquery.Selection, rule TextRule) string {
+	for _, selector := range rule.Selectors {
+		text := strings.TrimSpace(strings.ReplaceAll(sel.Find(selector).Text(), "\t", " "))
+		if text = applyTextRule(text, rule); text != "" {
+			return text
+		}
+	}
+	return ""
+}
 
-	byDesc := e.getInnerText(doc, ".a-unordered-list.a-vertical.a-spacing-mini")
-	if byDesc == "" || len(strings.ReplaceAll(byDesc, " ", "")) == 0 {
-		byDesc = e.getInnerText(doc, ".a-expander-content.a-expander-partial-collapse-content")
+// getRuleTexts 按规则获取每个匹配元素的文本，使用第一个有结果的选择器 (私有方法)
+func (e *AmazonExtractor) getRuleTexts(doc *goquery.Document, rule TextRule) []string {
+	for _, selector := range rule.Selectors {
+		var texts []string
+		doc.Find(selector).Each(func(i int, s *goquery.Selection) {
+			if text := applyTextRule(strings.TrimSpace(s.Text()), rule); text != "" {
+				texts = append(texts, text)
+			}
+		})
+		if len(texts) > 0 {
+			return texts
+		}
 	}
+	return nil
+}
 
-	featureBullets := e.getInnerText(doc, "#feature-bullets")
-	bucketdividerDesc := e.getInnerText(doc, ".aplus-v2.desktop.celwidget")
-	spacingTopBase := e.getInnerText(doc, ".a-row.a-spacing-top-base")
-	productDescription := e.getInnerText(doc, "#productDescription")
-	productDiscount := e.getInnerText(doc, ".a-size-large.a-color-price.savingPriceOverride.aok-align-center.reinventPriceSavingsPercentageMargin.savingsPercentage")
+// applyTextRule 对文本执行规则中的后处理和正则 (私有方法)
+func applyTextRule(text string, rule TextRule) string {
+	for _, name := range rule.Post {
+		if process := textProcessors[name]; process != nil {
+			text = process(text)
+		}
+	}
+	if re := rulePattern(rule.Pattern); re != nil {
+		match := re.FindStringSubmatch(text)
+		switch {
+		case match == nil:
+			return ""
+		case len(match) > 1:
+			return strings.TrimSpace(match[1])
+		default:
+			return strings.TrimSpace(match[0])
+		}
+	}
+	return strings.TrimSpace(text)
+}
 
-	productPrice := strings.Split(e.getInnerText(doc, ".aok-offscreen"), " ")[0]
-	symbolFlag := false
+// getProductTextDetail 获取产品详细信息 (私有方法)
+// mp 为商品所在站点，用于按站点的数字格式解析价格
+func (e *AmazonExtractor) getProductTextDetail(doc *goquery.Document, mp Marketplace, rules *ProductRules) ProductDetail {
+	productTitle := e.getRuleText(doc, rules.Title)
+	byDesc := e.getRuleText(doc, rules.Desc)
+	featureBullets := e.getRuleText(doc, rules.Feature)
+	bucketdividerDesc := e.getRuleText(doc, rules.APlusDesc)
+	spacingTopBase := e.getRuleText(doc, rules.ProductParameters)
+	productDescription := e.getRuleText(doc, rules.ProductDesc)
+	productDiscount := e.getRuleText(doc, rules.Discount)
+
+	// 兼容AMAZON FASHION网站：依次尝试价格选择器，直到找到带货币符号的价格
+	var productPrice string
 	currencySymbols := e.util.GetAllValues("currency_symbol")
-	for symbol := range currencySymbols {
-		if strings.Contains(productPrice, symbol) {
-			symbolFlag = true
+	for _, selector := range rules.Price.Selectors {
+		productPrice = strings.Split(e.getInnerText(doc, selector), " ")[0]
+		if containsAnySymbol(productPrice, currencySymbols) {
 			break
 		}
 	}
 
-	if !symbolFlag {
-		// 兼容AMAZON FASHION网站
-		productPrice = strings.Split(e.getInnerText(doc, ".a-offscreen"), " ")[0]
+	// 💰 完整的价格文本（按空格切分会截断 "1 299,99 €" 这类写法）
+	priceText := e.getRuleText(doc, rules.Price)
+	if priceText == "" {
+		priceText = productPrice
 	}
 
 	priceFloat := e.util.ExtractPrice(productPrice)
-	language := e.util.FindValueForCurrency(productPrice, "language_code")
+	language := e.util.FindValueForCurrency(priceText, "language_code")
 
 	var price *string
+	var priceMoney *Money
+	if money, ok := ParseMoney(priceText, mp); ok {
+		priceMoney = &money
+		priceFloat = new(float64)
+		*priceFloat = money.Float64()
+	}
 	if priceFloat != nil {
 		currencySymbol := e.util.FindValue(language, "language_code", "currency_symbol")
 		priceStr := currencySymbol + fmt.Sprintf("%.2f", *priceFloat)
@@ -78,32 +153,100 @@ func (e *AmazonExtractor) getProductTextDetail(doc *goquery.Document) ProductDet
 		Title:             productTitle,
 		ByDesc:            byDesc,
 		Feature:           featureBullets,
+		FeatureBullets:    e.getRuleTexts(doc, rules.FeatureBullets),
 		BucketdividerDesc: bucketdividerDesc,
 		ProductParameters: spacingTopBase,
+		Specs:             e.getSpecs(doc, rules.Specs),
 		ProductDesc:       productDescription,
 		ProductDiscount:   discount,
 		ProductPrice:      price,
+		ProductPriceMoney: priceMoney,
 		Language:          language,
 	}
 }
 
+// containsAnySymbol 文本中是否包含任意一个货币符号 (私有方法)
+func containsAnySymbol(text string, symbols map[string]bool) bool {
+	for symbol := range symbols {
+		if strings.Contains(text, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSpecs 获取技术细节和商品信息中的规格参数 (私有方法)
+// 同时兼容表格布局（th/td）和列表布局（detailBullets），同名参数只保留首次出现的值
+func (e *AmazonExtractor) getSpecs(doc *goquery.Document, rule SpecRule) []ProductSpec {
+	var specs []ProductSpec
+	seen := make(map[string]bool)
+	add := func(name, value string) {
+		name = strings.TrimSpace(strings.TrimSuffix(cleanText(name), ":"))
+		value = cleanText(value)
+		if name == "" || value == "" || seen[strings.ToLower(name)] {
+			return
+		}
+		seen[strings.ToLower(name)] = true
+		specs = append(specs, ProductSpec{Name: name, Value: value})
+	}
+
+	// 表格布局：技术细节、附加信息、商品概览
+	if len(rule.Rows) > 0 {
+		doc.Find(strings.Join(rule.Rows, ", ")).Each(func(i int, row *goquery.Selection) {
+			if th := row.Find("th"); th.Length() > 0 {
+				add(th.First().Text(), row.Find("td").First().Text())
+				return
+			}
+			if cells := row.Find("td"); cells.Length() >= 2 {
+				add(cells.Eq(0).Text(), cells.Eq(1).Text())
+			}
+		})
+	}
+
+	// 列表布局："Brand : Sony"
+	if len(rule.Items) > 0 && rule.Name != "" {
+		doc.Find(strings.Join(rule.Items, ", ")).Each(func(i int, item *goquery.Selection) {
+			name := item.Find(rule.Name).First()
+			if name.Length() == 0 {
+				return
+			}
+			add(name.Text(), strings.TrimPrefix(item.Text(), name.Text()))
+		})
+	}
+
+	return specs
+}
+
+// cleanText 去除不可见的方向标记并合并空白 (私有方法)
+func cleanText(text string) string {
+	text = strings.NewReplacer("\u200e", "", "\u200f", "", "\u00a0", " ").Replace(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
 // getImgSrc 获取产品图片URL列表 (私有方法)
-func (e *AmazonExtractor) getImgSrc(content string) []string {
+func (e *AmazonExtractor) getImgSrc(content string, rule ImageRule) []string {
 	// 图片JSON解析
-	patternImgObj := regexp.MustCompile(`P\.when\('A'\)\.register\("ImageBlockATF", function\(A\)\{\s*var data = \{\s*'enableS2WithoutS1': [True|true|False|false]+\,\s*'notShowVideoCount': [True|true|False|false]+\,\s*'colorImages': \{ 'initial': (.*?)\}\,\s*'colorToAsin'`)
-	matches := patternImgObj.FindStringSubmatch(content)
+	for _, pattern := range rule.Patterns {
+		re := rulePattern(pattern)
+		if re == nil {
+			continue
+		}
+		matches := re.FindStringSubmatch(content)
+		if len(matches) < 2 {
+			continue
+		}
 
-	if len(matches) > 1 {
 		totalImageJSONStr := strings.ReplaceAll(matches[1], "\\", "")
 		var totalImageJSON []map[string]interface{}
 
 		if err := json.Unmarshal([]byte(totalImageJSONStr), &totalImageJSON); err == nil {
 			var images []string
 			for _, row := range totalImageJSON {
-				if hiResImg, ok := row["hiRes"].(string); ok && hiResImg != "" {
-					images = append(images, hiResImg)
-				} else if largeImg, ok := row["large"].(string); ok && largeImg != "" {
-					images = append(images, largeImg)
+				for _, field := range rule.Fields {
+					if img, ok := row[field].(string); ok && img != "" {
+						images = append(images, img)
+						break
+					}
 				}
 			}
 			return images
@@ -111,17 +254,21 @@ func (e *AmazonExtractor) getImgSrc(content string) []string {
 	}
 
 	// 备用图片提取方法
-	imgPattern := regexp.MustCompile(`register.*?var data = (.*?)colorToAsin`)
-	if cleanContentMatch := imgPattern.FindStringSubmatch(content); len(cleanContentMatch) > 1 {
-		content = cleanContentMatch[1]
+	if imgPattern := rulePattern(rule.FallbackScope); imgPattern != nil {
+		if cleanContentMatch := imgPattern.FindStringSubmatch(content); len(cleanContentMatch) > 1 {
+			content = cleanContentMatch[1]
+		}
 	}
 
-	imgURLRegex := regexp.MustCompile(`(https://m\.media-amazon\.com/images/I/.*?)":\s?\[(\d+),\s?\d+\]`)
+	imgURLRegex := rulePattern(rule.FallbackURL)
+	if imgURLRegex == nil {
+		return nil
+	}
 	imgMatches := imgURLRegex.FindAllStringSubmatch(content, -1)
 
 	var srcs []string
 	for _, match := range imgMatches {
-		if len(match) >= 3 {
+		if len(match) >= 2 {
 			url := match[1]
 			name := strings.Split(filepath.Base(url), "_")[0]
 			fix := strings.Split(filepath.Base(url), "_")
@@ -145,24 +292,28 @@ func (e *AmazonExtractor) getImgSrc(content string) []string {
 }
 
 // getVideos 获取产品视频URL列表 (私有方法)
-func (e *AmazonExtractor) getVideos(text string, doc *goquery.Document, aplus string) []string {
+func (e *AmazonExtractor) getVideos(text string, doc *goquery.Document, rule VideoRule) []string {
 	var videos []string
 
 	// Aplus视频提取
-	if aplus != "" {
-		aplusPattern := regexp.MustCompile(`https://m\.media-amazon\.com/images/[A-Za-z0-9\-/_]+\.mp4`)
-		matches := aplusPattern.FindAllString(aplus, -1)
-		videos = append(videos, matches...)
+	if aplusPattern := rulePattern(rule.APlusPattern); aplusPattern != nil && rule.APlus != "" {
+		if aplus, _ := doc.Find(rule.APlus).Html(); aplus != "" {
+			videos = append(videos, aplusPattern.FindAllString(aplus, -1)...)
+		}
 	}
 
 	// 视频JSON解析
-	patternObj := regexp.MustCompile(`P\.when\('A'\)\.execute\('triggerVideoAjax', function\(A\)\{\nvar obj = A\.\$\.parseJSON\('(.*?)'\);\nA\.trigger\('enableS2WithoutS1Ajax`)
-	jsonMatches := patternObj.FindAllStringSubmatch(text, -1)
+	var jsonMatches [][]string
+	for _, pattern := range rule.JSONPatterns {
+		if re := rulePattern(pattern); re != nil {
+			jsonMatches = append(jsonMatches, re.FindAllStringSubmatch(text, -1)...)
+		}
+	}
 
 	for _, match := range jsonMatches {
 		if len(match) > 1 {
 			jsonStr := match[1]
-			if strings.HasPrefix(jsonStr, `{"dataInJson"`) && strings.HasSuffix(jsonStr, "}") {
+			if strings.HasPrefix(jsonStr, rule.JSONPrefix) && strings.HasSuffix(jsonStr, "}") {
 				jsonStr = strings.ReplaceAll(jsonStr, "\\", "")
 				var jsonInfo map[string]interface{}
 
@@ -181,21 +332,15 @@ func (e *AmazonExtractor) getVideos(text string, doc *goquery.Document, aplus st
 		}
 	}
 
-	// 轮播卡片视频提取
-	doc.Find("li._vse-vw-dp-card_style_carouselElement__AVBU9").Each(func(i int, s *goquery.Selection) {
-		if div := s.Find("div").First(); div.Length() > 0 {
-			if videoURL, exists := div.Attr("data-video-url"); exists && videoURL != "" {
+	// 轮播卡片、评论视频提取
+	for _, element := range rule.Elements {
+		doc.Find(element.Selector).Each(func(i int, s *goquery.Selection) {
+			if videoURL, exists := s.Attr(element.Attr); exists && videoURL != "" {
 				videos = append(videos, videoURL)
 			}
-		}
-	})
-
-	// 评论视频提取
-	doc.Find("div[id^='review-video-id-']").Each(func(i int, s *goquery.Selection) {
-		if videoURL, exists := s.Attr("data-video-url"); exists && videoURL != "" {
-			videos = append(videos, videoURL)
-		}
-	})
+		})
+	}
+	videos = append(videos, e.getReviewVideos(doc.Selection, rule)...)
 
 	// 去重
 	uniqueVideos := make(map[string]bool)
@@ -208,17 +353,32 @@ func (e *AmazonExtractor) getVideos(text string, doc *goquery.Document, aplus st
 	}
 
 	// 生成m3u8和mp4列表
-	_, m3u8URLList, mp4List := e.generateIDM3u8Mp4List(result)
+	_, m3u8URLList, mp4List := e.generateIDM3u8Mp4List(result, rulePattern(rule.StreamID))
 	return append(m3u8URLList, mp4List...)
 }
 
+// getReviewVideos 获取评论区视频URL列表 (私有方法)
+// 商品详情页与评论页使用相同的评论视频结构，均按 rule.Reviews 查找
+func (e *AmazonExtractor) getReviewVideos(sel *goquery.Selection, rule VideoRule) []string {
+	var videos []string
+	for _, element := range rule.Reviews {
+		sel.Find(element.Selector).Each(func(i int, s *goquery.Selection) {
+			if videoURL, exists := s.Attr(element.Attr); exists && videoURL != "" {
+				videos = append(videos, videoURL)
+			}
+		})
+	}
+	return videos
+}
+
 // generateIDM3u8Mp4List 生成视频ID、m3u8和mp4 URL列表 (私有方法)
-func (e *AmazonExtractor) generateIDM3u8Mp4List(videos []string) ([]string, []string, []string) {
+// streamID 提取m3u8中的视频ID，为nil时忽略所有m3u8
+func (e *AmazonExtractor) generateIDM3u8Mp4List(videos []string, streamID *regexp.Regexp) ([]string, []string, []string) {
 	var idList, m3u8URLList, mp4URLList []string
 
 	for _, video := range videos {
-		if strings.HasSuffix(video, ".m3u8") {
-			match := regexp.MustCompile(`-prod/(.*?)/`).FindStringSubmatch(video)
+		if strings.HasSuffix(video, ".m3u8") && streamID != nil {
+			match := streamID.FindStringSubmatch(video)
 			if len(match) > 1 {
 				idList = append(idList, match[1])
 				m3u8URLList = append(m3u8URLList, video)
@@ -240,19 +400,30 @@ func (e *AmazonExtractor) GetProductDetail(url, text string) ProductResult {
 		return ProductResult{}
 	}
 
-	productDetail := e.getProductTextDetail(doc)
-	srcs := e.getImgSrc(text)
+	// 🌍 按链接域名识别站点，未知域名按美国站处理
+	mp, known := MarketplaceByDomain(url)
+	if !known {
+		mp = MarketplaceUS
+	}
+
+	// 📐 整个页面使用同一份规则，解析过程中规则被替换也不受影响
+	rules := &e.Rules().Product
+
+	productDetail := e.getProductTextDetail(doc, mp, rules)
+	srcs := e.getImgSrc(text, rules.Images)
+	videos := e.getVideos(text, doc, rules.Videos)
 
-	aplus := doc.Find("#aplus")
-	var aplusHTML string
-	if aplus.Length() > 0 {
-		aplusHTML, _ = aplus.Html()
+	// 🌍 已知站点以站点语言为准（欧元等多国共用的货币无法区分语言）
+	if known {
+		productDetail.Language = mp.LanguageCode()
 	}
 
-	videos := e.getVideos(text, doc, aplusHTML)
+	asin, _ := ExtractASIN(url)
+	breadcrumbs := e.getBreadcrumbs(doc, mp, rules.Breadcrumbs)
 
 	return ProductResult{
 		LinkURL:  url,
+		ASIN:     asin,
 		Title:    productDetail.Title,
 		Desc:     productDetail.ByDesc,
 		Language: productDetail.Language,
@@ -260,5 +431,18 @@ func (e *AmazonExtractor) GetProductDetail(url, text string) ProductResult {
 		Videos:   videos,
 		Price:    productDetail.ProductPrice,
 		Discount: productDetail.ProductDiscount,
+
+		PriceMoney: productDetail.ProductPriceMoney,
+
+		Feature:           productDetail.Feature,
+		FeatureBullets:    productDetail.FeatureBullets,
+		APlusDesc:         productDetail.BucketdividerDesc,
+		ProductParameters: productDetail.ProductParameters,
+		Specs:             productDetail.Specs,
+		ProductDesc:       productDetail.ProductDesc,
+
+		Rating:      e.getRating(doc, mp, rules.Rating),
+		SalesRanks:  e.getSalesRanks(doc, mp, rules.SalesRanks, breadcrumbs),
+		Breadcrumbs: breadcrumbs,
 	}
 }
@@ -0,0 +1,168 @@
+package amazon
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// 🌍 Marketplace 亚马逊站点
+//
+// 描述一个地区站点的域名、请求语言、货币和数字格式，
+// 货币符号和语言代码取自 CountriesInfo 中对应国家的记录
+type Marketplace struct {
+	Code              string `json:"code"`               // 站点代码，如 "US"、"JP"
+	Domain            string `json:"domain"`             // 域名，如 "www.amazon.co.jp"
+	Country           string `json:"country"`            // CountriesInfo 中的国家名称
+	AcceptLanguage    string `json:"accept_language"`    // 请求头 accept-language
+	Currency          string `json:"currency"`           // ISO 4217 货币代码
+	DecimalSeparator  string `json:"decimal_separator"`  // 小数点，如 "." 或 ","
+	GroupingSeparator string `json:"grouping_separator"` // 千分位分隔符，如 "," 或 "."
+}
+
+// 预置站点
+var (
+	MarketplaceUS = Marketplace{Code: "US", Domain: "www.amazon.com", Country: "美国", AcceptLanguage: "en-US,en;q=0.9", Currency: "USD", DecimalSeparator: ".", GroupingSeparator: ","}
+	MarketplaceUK = Marketplace{Code: "UK", Domain: "www.amazon.co.uk", Country: "英国", AcceptLanguage: "en-GB,en;q=0.9", Currency: "GBP", DecimalSeparator: ".", GroupingSeparator: ","}
+	MarketplaceDE = Marketplace{Code: "DE", Domain: "www.amazon.de", Country: "德国", AcceptLanguage: "de-DE,de;q=0.9,en;q=0.6", Currency: "EUR", DecimalSeparator: ",", GroupingSeparator: "."}
+	MarketplaceFR = Marketplace{Code: "FR", Domain: "www.amazon.fr", Country: "法国", AcceptLanguage: "fr-FR,fr;q=0.9,en;q=0.6", Currency: "EUR", DecimalSeparator: ",", GroupingSeparator: " "}
+	MarketplaceES = Marketplace{Code: "ES", Domain: "www.amazon.es", Country: "西班牙", AcceptLanguage: "es-ES,es;q=0.9,en;q=0.6", Currency: "EUR", DecimalSeparator: ",", GroupingSeparator: "."}
+	MarketplaceIT = Marketplace{Code: "IT", Domain: "www.amazon.it", Country: "意大利", AcceptLanguage: "it-IT,it;q=0.9,en;q=0.6", Currency: "EUR", DecimalSeparator: ",", GroupingSeparator: "."}
+	MarketplaceJP = Marketplace{Code: "JP", Domain: "www.amazon.co.jp", Country: "日本", AcceptLanguage: "ja-JP,ja;q=0.9,en;q=0.6", Currency: "JPY", DecimalSeparator: ".", GroupingSeparator: ","}
+	MarketplaceIN = Marketplace{Code: "IN", Domain: "www.amazon.in", Country: "印度", AcceptLanguage: "en-IN,en;q=0.9,hi;q=0.6", Currency: "INR", DecimalSeparator: ".", GroupingSeparator: ","}
+)
+
+// marketplaces 按站点代码索引的预置站点
+var marketplaces = map[string]Marketplace{
+	MarketplaceUS.Code: MarketplaceUS,
+	MarketplaceUK.Code: MarketplaceUK,
+	MarketplaceDE.Code: MarketplaceDE,
+	MarketplaceFR.Code: MarketplaceFR,
+	MarketplaceES.Code: MarketplaceES,
+	MarketplaceIT.Code: MarketplaceIT,
+	MarketplaceJP.Code: MarketplaceJP,
+	MarketplaceIN.Code: MarketplaceIN,
+}
+
+// Marketplaces 返回所有预置站点，按站点代码排序
+func Marketplaces() []Marketplace {
+	list := make([]Marketplace, 0, len(marketplaces))
+	for _, m := range marketplaces {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// MarketplaceByCode 按站点代码查找站点（忽略大小写），"GB" 等同于 "UK"
+func MarketplaceByCode(code string) (Marketplace, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "GB" {
+		code = MarketplaceUK.Code
+	}
+	m, ok := marketplaces[code]
+	return m, ok
+}
+
+// MarketplaceByDomain 按域名或URL查找站点
+//
+// 支持 "amazon.de"、"www.amazon.de" 和 "https://www.amazon.de/dp/..." 等形式
+func MarketplaceByDomain(hostOrURL string) (Marketplace, bool) {
+	host := strings.TrimSpace(hostOrURL)
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return Marketplace{}, false
+		}
+		host = u.Hostname()
+	}
+	host = strings.ToLower(strings.TrimPrefix(host, "www."))
+
+	for _, m := range marketplaces {
+		if strings.TrimPrefix(m.Domain, "www.") == host {
+			return m, true
+		}
+	}
+	return Marketplace{}, false
+}
+
+// LookupMarketplace 按站点代码或域名查找站点
+func LookupMarketplace(codeOrDomain string) (Marketplace, bool) {
+	if m, ok := MarketplaceByCode(codeOrDomain); ok {
+		return m, true
+	}
+	return MarketplaceByDomain(codeOrDomain)
+}
+
+// CountryInfo 返回站点在 CountriesInfo 中对应的国家信息，找不到时返回美国
+func (m Marketplace) CountryInfo() CountryInfo {
+	if country, ok := lookupCountryInfo(m.Country); ok {
+		return country
+	}
+	country, _ := lookupCountryInfo(MarketplaceUS.Country)
+	return country
+}
+
+// 🔧 lookupCountryInfo 按国家名称查找 CountriesInfo 中的记录
+func lookupCountryInfo(name string) (CountryInfo, bool) {
+	for _, country := range CountriesInfo {
+		if country.Country == name {
+			return country, true
+		}
+	}
+	return CountryInfo{}, false
+}
+
+// LanguageCode 站点语言代码（取自 CountriesInfo）
+func (m Marketplace) LanguageCode() string {
+	return m.CountryInfo().LanguageCode
+}
+
+// CurrencySymbol 站点货币符号（取自 CountriesInfo）
+func (m Marketplace) CurrencySymbol() string {
+	return m.CountryInfo().CurrencySymbol
+}
+
+// BaseURL 站点首页地址，如 https://www.amazon.de
+func (m Marketplace) BaseURL() string {
+	return "https://" + m.Domain
+}
+
+// ProductURL 商品详情页地址
+func (m Marketplace) ProductURL(asin string) string {
+	return m.BaseURL() + "/dp/" + asin
+}
+
+// SearchURL 关键词搜索地址
+func (m Marketplace) SearchURL() string {
+	return m.BaseURL() + "/s"
+}
+
+// ReviewsURL 商品评论页地址
+func (m Marketplace) ReviewsURL(asin string) string {
+	return m.BaseURL() + "/product-reviews/" + asin + "/"
+}
+
+// OffersURL 商品报价列表（AOD）地址，ASIN和页码通过查询参数传递
+func (m Marketplace) OffersURL() string {
+	return m.BaseURL() + "/gp/aod/ajax"
+}
+
+// ShopLookURL 图片搜索获取stylesnap令牌的页面地址
+func (m Marketplace) ShopLookURL() string {
+	return m.BaseURL() + "/shopthelook"
+}
+
+// StyleSnapUploadURL 图片搜索上传地址
+func (m Marketplace) StyleSnapUploadURL() string {
+	return m.BaseURL() + "/stylesnap/upload"
+}
+
+// LocaleHeaders 站点相关的请求头（accept-language、referer）
+// 🔧 在请求级别覆盖 AmazonHeaders 中的默认值
+func (m Marketplace) LocaleHeaders() map[string]string {
+	return map[string]string{
+		"accept-language": m.AcceptLanguage,
+		"referer":         m.BaseURL(),
+	}
+}
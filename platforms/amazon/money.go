@@ -0,0 +1,193 @@
+package amazon
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 💰 Money 金额
+//
+// Amount 以最小货币单位保存（美元为美分，日元为元），避免浮点误差；
+// Text 保留页面上的原始文本，方便核对
+type Money struct {
+	Amount   int64  `json:"amount"`   // 最小货币单位的金额，如 129999 表示 1299.99
+	Currency string `json:"currency"` // ISO 4217 货币代码，如 "EUR"
+	Text     string `json:"text"`     // 原始文本，如 "1.299,99 €"
+}
+
+// Float64 以主货币单位返回金额，如 1299.99
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(MinorDigits(m.Currency))
+}
+
+// String 返回 "1299.99 EUR" 形式的金额
+func (m Money) String() string {
+	return fmt.Sprintf("%.*f %s", MinorDigits(m.Currency), m.Float64(), m.Currency)
+}
+
+// MinorDigits 货币的小数位数（日元为0，其余为2）
+func MinorDigits(currency string) int {
+	switch currency {
+	case "JPY", "KRW", "VND":
+		return 0
+	}
+	return 2
+}
+
+var (
+	// 金额中的数字及分隔符，如 "1.299,99"、"1 299,99"、"1,299.99"
+	moneyNumberPattern = regexp.MustCompile(`\d+(?:[.,\x{00a0}\x{202f} ]\d+)*`)
+	// 文本中的ISO货币代码
+	currencyCodePattern = regexp.MustCompile(`\b(USD|GBP|EUR|JPY|CNY|INR)\b`)
+	// 带货币符号的价格，符号在前或在后，如 "$36.79"、"1 299,99 €"
+	moneyTextPattern = regexp.MustCompile(`[$£€¥￥₹]\s?\d(?:[\d.,\x{00a0}\x{202f} ]*\d)?|\d(?:[\d.,\x{00a0}\x{202f} ]*\d)?[\s\x{00a0}]?[$£€¥￥₹]`)
+)
+
+// currencySymbols 货币符号对应的ISO货币代码，多个候选时优先使用站点货币
+var currencySymbols = []struct {
+	symbol     string
+	currencies []string
+}{
+	{"₹", []string{"INR"}},
+	{"£", []string{"GBP"}},
+	{"€", []string{"EUR"}},
+	{"¥", []string{"JPY", "CNY"}},
+	{"￥", []string{"JPY", "CNY"}},
+	{"$", []string{"USD"}},
+}
+
+// 🔍 ParseMoney 按站点的数字格式解析金额
+//
+// 同时支持符号在前（"$1,299.99"、"￥1,280"）和符号在后（"1.299,99 €"、"1 299,99 €"）的写法；
+// 货币按文本中的符号或ISO代码识别，识别不到时使用站点货币
+//
+// 参数:
+//   - text: 价格文本
+//   - mp: 价格所在站点，决定小数点/千分位分隔符的解释
+//
+// 返回:
+//   - Money: 解析出的金额
+//   - bool: 文本中没有数字或金额超出 int64 范围时返回false
+func ParseMoney(text string, mp Marketplace) (Money, bool) {
+	value, ok := parseDecimal(text, mp)
+	if !ok {
+		return Money{}, false
+	}
+
+	currency := detectCurrency(text, mp.Currency)
+	// 🔢 float64(math.MaxInt64) 即 2^63，不小于它的值转换为 int64 会溢出
+	minor := math.Round(value * math.Pow10(MinorDigits(currency)))
+	if !(minor < math.MaxInt64) {
+		return Money{}, false
+	}
+	return Money{
+		Amount:   int64(minor),
+		Currency: currency,
+		Text:     strings.TrimSpace(text),
+	}, true
+}
+
+// findMoneyText 返回文本中第一个带货币符号的价格，如 "$36.79 with 20 percent savings" 中的 "$36.79"
+func findMoneyText(text string) string {
+	return moneyTextPattern.FindString(text)
+}
+
+// detectCurrency 识别文本中的货币，识别不到时返回默认货币
+func detectCurrency(text, fallback string) string {
+	if match := currencyCodePattern.FindString(text); match != "" {
+		return match
+	}
+	for _, item := range currencySymbols {
+		if !strings.Contains(text, item.symbol) {
+			continue
+		}
+		for _, currency := range item.currencies {
+			if currency == fallback {
+				return currency
+			}
+		}
+		return item.currencies[0]
+	}
+	return fallback
+}
+
+// parseDecimal 按站点的数字格式解析文本中第一个数字，自动区分小数点与千分位分隔符
+//
+// 站点的 DecimalSeparator / GroupingSeparator 仅在无法判断时使用（如 "1.234" 在德国站为1234，
+// 在美国站为1.234）；站点为空时按千分位处理。判断规则:
+//   - 同时出现 "." 和 ","：最后出现的是小数点
+//   - 同一分隔符出现多次：千分位
+//   - 只出现一次且后面不是3位数字：小数点
+//   - 只出现一次且后面是3位数字：是站点小数点时为小数点，否则为千分位
+//   - 空格只在站点千分位为空格（法国站）或站点为空时作为千分位，否则数字到空格为止
+func parseDecimal(text string, mp Marketplace) (float64, bool) {
+	match := moneyNumberPattern.FindString(text)
+	if match == "" {
+		return 0, false
+	}
+
+	// 拆分为数字段和分隔符，空格只作为千分位（后面必须是3位数字），否则截断
+	spaceGrouping := mp.GroupingSeparator == "" || isSpaceSep(mp.GroupingSeparator)
+	var groups, seps []string
+	start := 0
+	for i, r := range match {
+		if r >= '0' && r <= '9' {
+			continue
+		}
+		if i > start {
+			groups = append(groups, match[start:i])
+		}
+		seps = append(seps, string(r))
+		start = i + len(string(r))
+	}
+	groups = append(groups, match[start:])
+	for i, sep := range seps {
+		if isSpaceSep(sep) && (!spaceGrouping || len(groups[i+1]) != 3) {
+			groups, seps = groups[:i+1], seps[:i]
+			break
+		}
+	}
+
+	decimalIndex := -1
+	if n := len(seps); n > 0 && !isSpaceSep(seps[n-1]) {
+		last := seps[n-1]
+		count, mixed := 0, false
+		for _, sep := range seps {
+			if sep == last {
+				count++
+			} else if !isSpaceSep(sep) {
+				mixed = true
+			}
+		}
+		switch {
+		case count > 1:
+			// 同一分隔符重复出现，只能是千分位
+		case mixed, len(groups[n]) != 3:
+			decimalIndex = n - 1
+		case last == mp.DecimalSeparator:
+			// "1,234" 在德国站为1.234
+			decimalIndex = n - 1
+		}
+	}
+
+	var number strings.Builder
+	for i, group := range groups {
+		number.WriteString(group)
+		if i == decimalIndex {
+			number.WriteString(".")
+		}
+	}
+	value, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// isSpaceSep 判断是否为空格类千分位分隔符（法国站使用）
+func isSpaceSep(sep string) bool {
+	return sep == " " || sep == "\u00a0" || sep == "\u202f"
+}
@@ -0,0 +1,240 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-resty/resty/v2"
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// 🏷️ FetchOffers 抓取商品的报价列表（All Offers Display）
+//
+// 返回每个卖家报价的卖家名称与ID、商品状况、价格、运费、Prime/FBA标记、预计送达时间，
+// 以及当前赢得购物车（buy box）的报价。价格按站点的数字格式解析为 Money
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求的生命周期
+//   - asin: 商品ASIN
+//   - opts: 站点与分页选项
+//
+// 返回:
+//   - OfferResult: 所有已抓取页面的报价，购物车报价排在最前
+//   - error: 错误信息，如果没有错误则为nil
+func (s *AmazonSpider) FetchOffers(ctx context.Context, asin string, opts OfferOptions) (OfferResult, error) {
+	asin = strings.ToUpper(strings.TrimSpace(asin))
+	if !IsValidASIN(asin) {
+		return OfferResult{}, fmt.Errorf("❌ 无效的ASIN: %s", asin)
+	}
+
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+	mp := s.marketplaceOr(opts.Marketplace)
+
+	result := OfferResult{ASIN: asin}
+	listed := 0
+	for page := 1; page <= maxPages; page++ {
+		var offerPage OfferPage
+		err := s.withRetry(ctx, func() (err error) {
+			offerPage, err = s.fetchOfferPage(ctx, mp, asin, page)
+			return err
+		})
+		if err != nil {
+			// 已抓取到部分结果时一并返回，方便调用方保留进度
+			return result, fmt.Errorf("❌ 抓取第%d页报价失败: %w", page, err)
+		}
+
+		if offerPage.BuyBox != nil && result.BuyBox == nil {
+			buyBox := *offerPage.BuyBox
+			result.BuyBox = &buyBox
+			result.Offers = append([]Offer{buyBox}, result.Offers...)
+			// 报价总数包含固定区域的购物车报价
+			listed++
+		}
+		if offerPage.TotalOffers > 0 {
+			result.TotalOffers = offerPage.TotalOffers
+		}
+		result.Offers = append(result.Offers, offerPage.Offers...)
+		listed += len(offerPage.Offers)
+		result.LastPage = page
+
+		// 📄 没有报价总数时无法判断是否还有下一页，按没有处理
+		result.HasNextPage = len(offerPage.Offers) > 0 && listed < result.TotalOffers
+		if !result.HasNextPage {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// 🔧 fetchOfferPage 请求并解析单页报价
+func (s *AmazonSpider) fetchOfferPage(ctx context.Context, mp Marketplace, asin string, page int) (OfferPage, error) {
+	resp, err := s.execute(ctx, "", func(req *resty.Request) (*resty.Response, error) {
+		return req.
+			SetHeaders(mp.LocaleHeaders()).
+			SetHeader("referer", mp.ProductURL(asin)).
+			SetQueryParams(map[string]string{
+				"asin":         asin,
+				"pc":           "dp",
+				"experienceId": "aodAjaxMain",
+				"pageno":       strconv.Itoa(page),
+			}).
+			Get(mp.OffersURL())
+	})
+	if err != nil {
+		return OfferPage{}, fmt.Errorf("请求失败: %w", err)
+	}
+
+	if err := checkPage(resp); err != nil {
+		return OfferPage{}, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return OfferPage{}, errs.NewHTTPError(PlatformName, resp.Request.URL, resp.StatusCode(), resp.Body())
+	}
+
+	return s.extractor.GetOfferPage(resp.Request.URL, string(resp.Body())), nil
+}
+
+// GetOfferPage 解析报价列表页 (公开方法)
+// pageURL 用于识别站点以解析价格，未知域名按美国站处理
+func (e *AmazonExtractor) GetOfferPage(pageURL, text string) OfferPage {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return OfferPage{}
+	}
+
+	mp, known := MarketplaceByDomain(pageURL)
+	if !known {
+		mp = MarketplaceUS
+	}
+	rules := &e.Rules().Offers
+
+	var page OfferPage
+	if len(rules.Pinned) > 0 {
+		// 🛒 没有购物车的商品，固定区域只有提示文本，没有价格
+		pinned := doc.Find(strings.Join(rules.Pinned, ", ")).First()
+		if pinned.Length() > 0 {
+			if offer := e.getOffer(pinned, mp, rules); offer.Price != nil {
+				offer.BuyBoxWinner = true
+				page.BuyBox = &offer
+			}
+		}
+	}
+	if len(rules.Offers) > 0 {
+		doc.Find(strings.Join(rules.Offers, ", ")).Each(func(i int, s *goquery.Selection) {
+			if offer := e.getOffer(s, mp, rules); offer.Price != nil || offer.SellerName != "" {
+				page.Offers = append(page.Offers, offer)
+			}
+		})
+	}
+	if rules.Total.Selector != "" {
+		total := doc.Find(rules.Total.Selector).First().AttrOr(rules.Total.Attr, "")
+		page.TotalOffers, _ = strconv.Atoi(strings.TrimSpace(total))
+	}
+	return page
+}
+
+// getOffer 解析单个报价元素 (私有方法)
+func (e *AmazonExtractor) getOffer(s *goquery.Selection, mp Marketplace, rules *OfferRules) Offer {
+	offer := Offer{
+		SellerName: e.getRuleTextIn(s, rules.SellerName),
+		Condition:  e.getRuleTextIn(s, rules.Condition),
+		ShipsFrom:  e.getRuleTextIn(s, rules.ShipsFrom),
+	}
+	if text := e.getRuleTextIn(s, rules.Price); text != "" {
+		if money, ok := ParseMoney(text, mp); ok {
+			offer.Price = &money
+		}
+	}
+	if rules.Shipping.Selector != "" {
+		text := cleanText(s.Find(rules.Shipping.Selector).First().AttrOr(rules.Shipping.Attr, ""))
+		offer.Shipping = shippingMoney(text, mp, rules.FreeWords)
+	}
+	if rules.Delivery.Selector != "" {
+		offer.DeliveryEstimate = cleanText(s.Find(rules.Delivery.Selector).First().AttrOr(rules.Delivery.Attr, ""))
+	}
+	if rules.Prime != "" {
+		offer.Prime = s.Find(rules.Prime).Length() > 0
+	}
+
+	// 🏪 卖家ID和FBA标记来自卖家链接的查询参数
+	var fbaLink bool
+	if rules.SellerLink.Selector != "" {
+		if link, ok := s.Find(rules.SellerLink.Selector).First().Attr(rules.SellerLink.Attr); ok {
+			if u, err := url.Parse(link); err == nil {
+				offer.SellerID = u.Query().Get("seller")
+				fbaLink = u.Query().Get("isAmazonFulfilled") == "1"
+			}
+		}
+	}
+	offer.SoldByAmazon = isAmazonSeller(offer.SellerName, offer.SellerID, mp)
+	offer.FulfilledByAmazon = fbaLink || isAmazonName(offer.ShipsFrom)
+	return offer
+}
+
+// shippingMoney 解析运费文本，免运费时金额为0，文本为空或无法解析时返回nil (私有方法)
+func shippingMoney(text string, mp Marketplace, freeWords []string) *Money {
+	if text == "" {
+		return nil
+	}
+	upper := strings.ToUpper(text)
+	for _, word := range freeWords {
+		if word != "" && strings.Contains(upper, strings.ToUpper(word)) {
+			return &Money{Currency: mp.Currency, Text: text}
+		}
+	}
+	if money, ok := ParseMoney(text, mp); ok {
+		return &money
+	}
+	return nil
+}
+
+// amazonSellerIDs 各站点亚马逊自营的卖家ID
+var amazonSellerIDs = map[string]string{
+	MarketplaceUS.Code: "ATVPDKIKX0DER",
+	MarketplaceUK.Code: "A3P5ROKL5A1OLE",
+	MarketplaceDE.Code: "A3JWKAKR8XB7XF",
+	MarketplaceFR.Code: "A1X6FK5RDHNB96",
+	MarketplaceES.Code: "A1AT7YVPFBWXBL",
+	MarketplaceIT.Code: "A11IL2PNWYJU7H",
+	MarketplaceJP.Code: "AN1VRQENFRJN5",
+}
+
+// amazonNames 亚马逊自营时显示的卖家/发货方名称（小写），"Amazon.com" 等站点域名见 isAmazonName
+var amazonNames = map[string]bool{
+	"amazon":           true,
+	"amazon resale":    true,
+	"amazon warehouse": true,
+}
+
+// isAmazonSeller 卖家是否为亚马逊自营 (私有方法)
+// 有卖家ID时按站点的亚马逊卖家ID判断，否则按卖家名称判断
+func isAmazonSeller(name, sellerID string, mp Marketplace) bool {
+	if sellerID != "" {
+		return sellerID == amazonSellerIDs[mp.Code]
+	}
+	return isAmazonName(name)
+}
+
+// isAmazonName 卖家或发货方名称是否为亚马逊，如 "Amazon"、"Amazon.com"、"Amazon.co.jp" (私有方法)
+// 只匹配完整名称，"AmazonBasics Outlet Store" 等第三方卖家不算
+func isAmazonName(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if amazonNames[name] {
+		return true
+	}
+	for _, m := range marketplaces {
+		if name == strings.TrimPrefix(m.Domain, "www.") {
+			return true
+		}
+	}
+	return false
+}
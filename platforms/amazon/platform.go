@@ -0,0 +1,172 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xieburoucoco/spider-hub/platforms"
+)
+
+// 平台注册名称
+const PlatformName = "amazon"
+
+// 平台操作名称
+const (
+	ActionProduct     = "product"
+	ActionImageSearch = "image_search"
+	ActionSearch      = "search"
+	ActionReviews     = "reviews"
+	ActionVariations  = "variations"
+	ActionOffers      = "offers"
+)
+
+// 平台请求参数
+const (
+	ParamProxy       = "proxy"
+	ParamMarketplace = "marketplace"
+)
+
+func init() {
+	platforms.Register(NewAmazonPlatform(NewAmazonSpider()))
+}
+
+// 🧩 AmazonPlatform 亚马逊平台适配器
+// 将AmazonSpider包装为 platforms.Platform，供注册中心统一调用
+type AmazonPlatform struct {
+	spider *AmazonSpider
+}
+
+// 🏭 NewAmazonPlatform 使用指定的爬虫创建平台适配器
+func NewAmazonPlatform(spider *AmazonSpider) *AmazonPlatform {
+	return &AmazonPlatform{spider: spider}
+}
+
+// Name 平台名称
+func (p *AmazonPlatform) Name() string {
+	return PlatformName
+}
+
+// Capabilities 平台支持的操作
+func (p *AmazonPlatform) Capabilities() []platforms.Capability {
+	return []platforms.Capability{
+		{Action: ActionProduct, Description: "获取商品详情，返回ProductResult；Target为ASIN时使用marketplace参数指定站点", Target: "亚马逊商品URL或ASIN"},
+		{Action: ActionImageSearch, Description: "以图搜商品，返回[]ImageSearchProduct；Body非空时使用Body中的图片数据", Target: "图片URL"},
+		{Action: ActionSearch, Description: "关键词搜索商品，返回KeywordSearchResult；支持page、max_pages、page_size、marketplace参数", Target: "搜索关键词"},
+		{Action: ActionReviews, Description: "抓取商品评论，返回ReviewResult；支持star、sort_by、verified、page、max_pages、marketplace参数", Target: "商品ASIN"},
+		{Action: ActionVariations, Description: "获取商品变体矩阵，返回VariationResult；details=true时同时获取每个子ASIN的详情，支持marketplace参数", Target: "父ASIN或子ASIN"},
+		{Action: ActionOffers, Description: "抓取商品报价列表和购物车报价，返回OfferResult；支持max_pages、marketplace参数", Target: "商品ASIN"},
+	}
+}
+
+// Fetch 执行抓取请求
+//
+// 支持的参数:
+//   - proxy: 代理地址（仅图片搜索）
+//   - marketplace: 站点代码或域名（如 JP、amazon.de），用于关键词搜索、评论、变体、报价和按ASIN获取商品
+//   - page、max_pages、page_size: 关键词搜索的分页选项（评论支持 page、max_pages，报价支持 max_pages）
+//   - star、sort_by、verified: 评论筛选选项（verified=true只看已验证购买）
+//   - details: 获取变体时是否同时获取每个子ASIN的详情（details=true）
+func (p *AmazonPlatform) Fetch(ctx context.Context, req platforms.Request) (platforms.Result, error) {
+	result := platforms.Result{Platform: PlatformName, Action: req.Action}
+
+	var proxies map[string]string
+	if proxy := req.Param(ParamProxy, ""); proxy != "" {
+		proxies = map[string]string{"http": proxy, "https": proxy}
+	}
+
+	var marketplace Marketplace
+	if code := req.Param(ParamMarketplace, ""); code != "" {
+		m, ok := LookupMarketplace(code)
+		if !ok {
+			return platforms.Result{}, fmt.Errorf("❌ 未知的亚马逊站点: %s", code)
+		}
+		marketplace = m
+	}
+
+	switch req.Action {
+	case ActionProduct:
+		var product ProductResult
+		var err error
+		if asin := strings.ToUpper(strings.TrimSpace(req.Target)); IsValidASIN(asin) {
+			product, err = p.spider.FetchProductByASIN(ctx, marketplace, asin)
+		} else {
+			product, err = p.spider.FetchProductDetail(ctx, req.Target)
+		}
+		if err != nil {
+			return platforms.Result{}, err
+		}
+		result.Data = product
+	case ActionImageSearch:
+		var products []ImageSearchProduct
+		var err error
+		if len(req.Body) > 0 {
+			products, err = p.spider.SearchProductsByImageData(ctx, req.Body, proxies)
+		} else {
+			products, err = p.spider.SearchProductsByImageURL(ctx, req.Target, proxies)
+		}
+		if err != nil {
+			return platforms.Result{}, err
+		}
+		result.Data = products
+	case ActionSearch:
+		opts := SearchOptions{
+			Page:     atoiOrZero(req.Param(Page, "")),
+			MaxPages: atoiOrZero(req.Param(MaxPages, "")),
+			PageSize: atoiOrZero(req.Param(PageSize, "")),
+
+			Marketplace: marketplace,
+		}
+		searchResult, err := p.spider.SearchProducts(ctx, req.Target, opts)
+		if err != nil {
+			return platforms.Result{}, err
+		}
+		result.Data = searchResult
+	case ActionReviews:
+		opts := ReviewOptions{
+			Star:         ReviewStarFilter(req.Param(Star, "")),
+			SortBy:       ReviewSort(req.Param(SortBy, "")),
+			VerifiedOnly: req.Param(Verified, "") == "true",
+			Page:         atoiOrZero(req.Param(Page, "")),
+			MaxPages:     atoiOrZero(req.Param(MaxPages, "")),
+
+			Marketplace: marketplace,
+		}
+		reviews, err := p.spider.FetchReviews(ctx, req.Target, opts)
+		if err != nil {
+			return platforms.Result{}, err
+		}
+		result.Data = reviews
+	case ActionVariations:
+		opts := VariationOptions{
+			FetchDetails: req.Param(Details, "") == "true",
+			Marketplace:  marketplace,
+		}
+		variations, err := p.spider.FetchVariations(ctx, req.Target, opts)
+		if err != nil {
+			return platforms.Result{}, err
+		}
+		result.Data = variations
+	case ActionOffers:
+		opts := OfferOptions{
+			MaxPages:    atoiOrZero(req.Param(MaxPages, "")),
+			Marketplace: marketplace,
+		}
+		offers, err := p.spider.FetchOffers(ctx, req.Target, opts)
+		if err != nil {
+			return platforms.Result{}, err
+		}
+		result.Data = offers
+	default:
+		return platforms.Result{}, fmt.Errorf("❌ %w: %s %s", platforms.ErrUnsupportedAction, PlatformName, req.Action)
+	}
+
+	return result, nil
+}
+
+// atoiOrZero 解析整数参数，无效时返回0（即使用默认值）
+func atoiOrZero(value string) int {
+	n, _ := strconv.Atoi(value)
+	return n
+}
@@ -0,0 +1,169 @@
+package amazon
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// 星级分布行中的星级，如 "5 star"、"5 Sterne"、"星5つ"
+	histogramStarPattern = regexp.MustCompile(`[1-5]`)
+	// 星级分布行中的百分比，如 "67%"、"67 %"
+	histogramPercentPattern = regexp.MustCompile(`(\d+)\s*%`)
+)
+
+// getRating 获取平均评分、评分总数和星级分布，都没有时返回nil (私有方法)
+func (e *AmazonExtractor) getRating(doc *goquery.Document, mp Marketplace, rule RatingRule) *Rating {
+	rating := Rating{Average: parseRating(e.getRuleText(doc, rule.Average), mp)}
+	rating.Count = digitsToInt(e.getRuleText(doc, rule.Count))
+
+	if len(rule.Histogram) > 0 {
+		doc.Find(strings.Join(rule.Histogram, ", ")).Each(func(i int, s *goquery.Selection) {
+			text := cleanText(s.Text())
+			star := histogramStarPattern.FindString(text)
+			match := histogramPercentPattern.FindStringSubmatch(text)
+			if star == "" || len(match) != 2 {
+				return
+			}
+			if rating.Histogram == nil {
+				rating.Histogram = make(map[int]int, 5)
+			}
+			rating.Histogram[int(star[0]-'0')], _ = strconv.Atoi(match[1])
+		})
+	}
+
+	if rating.Average == 0 && rating.Count == 0 && rating.Histogram == nil {
+		return nil
+	}
+	return &rating
+}
+
+// getBreadcrumbs 获取类目面包屑 (私有方法)
+func (e *AmazonExtractor) getBreadcrumbs(doc *goquery.Document, mp Marketplace, rule AttrRule) []Breadcrumb {
+	if rule.Selector == "" {
+		return nil
+	}
+
+	var breadcrumbs []Breadcrumb
+	doc.Find(rule.Selector).Each(func(i int, s *goquery.Selection) {
+		name := cleanText(s.Text())
+		if name == "" {
+			return
+		}
+		href := s.AttrOr(rule.Attr, "")
+		breadcrumb := Breadcrumb{Name: name, LinkURL: absoluteURL(mp, href)}
+		if u, err := url.Parse(href); err == nil {
+			breadcrumb.NodeID = u.Query().Get("node")
+		}
+		breadcrumbs = append(breadcrumbs, breadcrumb)
+	})
+	return breadcrumbs
+}
+
+// getSalesRanks 获取畅销排名，按面包屑补充类目路径 (私有方法)
+func (e *AmazonExtractor) getSalesRanks(doc *goquery.Document, mp Marketplace, rule SalesRankRule, breadcrumbs []Breadcrumb) []SalesRank {
+	pattern := rulePattern(rule.Pattern)
+	if len(rule.Rows) == 0 || pattern == nil {
+		return nil
+	}
+
+	// 📊 找到标签匹配的第一行
+	var row *goquery.Selection
+	doc.Find(strings.Join(rule.Rows, ", ")).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		label := strings.ToLower(cleanText(s.Find(rule.Label).First().Text()))
+		for _, want := range rule.Labels {
+			if label != "" && strings.Contains(label, strings.ToLower(want)) {
+				row = s.Clone()
+				return false
+			}
+		}
+		return true
+	})
+	if row == nil {
+		return nil
+	}
+	row.Find(rule.Label).Remove()
+
+	var ranks []SalesRank
+	add := func(s *goquery.Selection) {
+		match := pattern.FindStringSubmatch(cleanText(s.Text()))
+		if len(match) != 3 {
+			return
+		}
+		rank := SalesRank{Rank: digitsToInt(match[1]), Category: match[2]}
+		if rank.Rank == 0 || rank.Category == "" {
+			return
+		}
+		if href, ok := s.Find(rule.Link).First().Attr("href"); ok {
+			rank.LinkURL = absoluteURL(mp, href)
+			if re := rulePattern(rule.NodePattern); re != nil {
+				if m := re.FindStringSubmatch(href); len(m) > 1 {
+					rank.CategoryID = m[1]
+				}
+			}
+		}
+		rank.CategoryPath = categoryPath(rank, breadcrumbs)
+		ranks = append(ranks, rank)
+	}
+
+	entries := row.Find(rule.Entries)
+	entries.Remove()
+	add(row)
+	entries.Each(func(i int, s *goquery.Selection) { add(s) })
+	return ranks
+}
+
+// categoryPath 排名类目在面包屑中的路径（先按类目ID，再按名称匹配），找不到时只有类目本身 (私有方法)
+func categoryPath(rank SalesRank, breadcrumbs []Breadcrumb) []string {
+	index := -1
+	for i, b := range breadcrumbs {
+		if rank.CategoryID != "" && b.NodeID == rank.CategoryID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		for i, b := range breadcrumbs {
+			if strings.EqualFold(b.Name, rank.Category) {
+				index = i
+				break
+			}
+		}
+	}
+	if index < 0 {
+		return []string{rank.Category}
+	}
+
+	path := make([]string, 0, index+1)
+	for _, b := range breadcrumbs[:index+1] {
+		path = append(path, b.Name)
+	}
+	return path
+}
+
+// digitsToInt 去掉分隔符后转为整数，如 "12,345" → 12345 (私有方法)
+func digitsToInt(text string) int {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, text)
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// absoluteURL 将站内相对链接转为完整链接 (私有方法)
+func absoluteURL(mp Marketplace, href string) string {
+	if href == "" || strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+		return href
+	}
+	if !strings.HasPrefix(href, "/") {
+		href = "/" + href
+	}
+	return mp.BaseURL() + href
+}
@@ -0,0 +1,248 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-resty/resty/v2"
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// reviewDateFormat 评论日期行的写法（按站点语言）
+type reviewDateFormat struct {
+	pattern *regexp.Regexp    // 包含 country 和 date 两个命名分组
+	layouts []string          // 日期格式，月份为英文
+	months  *strings.Replacer // 小写的本地月份名称 → 英文，英文站为nil
+}
+
+// reviewDateFormats 各站点的评论日期写法，按文本匹配，不依赖请求的站点
+var reviewDateFormats = []reviewDateFormat{
+	// 🇺🇸🇬🇧🇮🇳 "Reviewed in the United States on January 2, 2024" / "Reviewed in India on 2 January 2024"
+	{
+		pattern: regexp.MustCompile(`^Reviewed in (?:the )?(?P<country>.+?) on (?P<date>.+)$`),
+		layouts: []string{"January 2, 2006", "2 January 2006"},
+	},
+	// 🇩🇪 "Rezension aus Deutschland vom 2. Januar 2024"
+	{
+		pattern: regexp.MustCompile(`^Rezension aus (?:dem |der |den )?(?P<country>.+?) vom (?P<date>.+)$`),
+		layouts: []string{"2. January 2006"},
+		months: strings.NewReplacer("januar", "January", "februar", "February", "märz", "March", "april", "April",
+			"mai", "May", "juni", "June", "juli", "July", "august", "August", "september", "September",
+			"oktober", "October", "november", "November", "dezember", "December"),
+	},
+	// 🇫🇷 "Commenté en France le 2 janvier 2024"
+	{
+		pattern: regexp.MustCompile(`^Commenté (?:en |au |aux )(?P<country>.+?) le (?P<date>.+)$`),
+		layouts: []string{"2 January 2006"},
+		months: strings.NewReplacer("janvier", "January", "février", "February", "mars", "March", "avril", "April",
+			"mai", "May", "juin", "June", "juillet", "July", "août", "August", "septembre", "September",
+			"octobre", "October", "novembre", "November", "décembre", "December"),
+	},
+	// 🇪🇸 "Revisado en España el 2 de enero de 2024"
+	{
+		pattern: regexp.MustCompile(`^Revisado en (?:los |el )?(?P<country>.+?) el (?P<date>.+)$`),
+		layouts: []string{"2 de January de 2006"},
+		months: strings.NewReplacer("enero", "January", "febrero", "February", "marzo", "March", "abril", "April",
+			"mayo", "May", "junio", "June", "julio", "July", "agosto", "August", "septiembre", "September",
+			"octubre", "October", "noviembre", "November", "diciembre", "December"),
+	},
+	// 🇮🇹 "Recensito in Italia il 2 gennaio 2024"
+	{
+		pattern: regexp.MustCompile(`^Recensito (?:in |negli |nel )(?P<country>.+?) il (?P<date>.+)$`),
+		layouts: []string{"2 January 2006"},
+		months: strings.NewReplacer("gennaio", "January", "febbraio", "February", "marzo", "March", "aprile", "April",
+			"maggio", "May", "giugno", "June", "luglio", "July", "agosto", "August", "settembre", "September",
+			"ottobre", "October", "novembre", "November", "dicembre", "December"),
+	},
+	// 🇯🇵 "2024年1月2日に日本でレビュー済み"
+	{
+		pattern: regexp.MustCompile(`^(?P<date>\d{4}年\d{1,2}月\d{1,2}日)に(?P<country>.+?)でレビュー済み$`),
+		layouts: []string{"2006年1月2日"},
+	},
+}
+
+var (
+	// "12 people found this helpful" / "1.234 Personen fanden das hilfreich" / "12人のお客様がこれが役に立ったと考えています"
+	// 只有一人时为 "One person ..." / "Eine Person ..." / "Une personne ..." / "Una persona ..."
+	helpfulVotesPattern = regexp.MustCompile(`^(\d[\d.,]*|One|Eine|Une|Una)[\s人]`)
+)
+
+// 💬 FetchReviews 抓取商品评论
+//
+// 按星级、排序方式、是否已验证购买筛选，从指定页开始连续翻页抓取评论
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求的生命周期
+//   - asin: 商品ASIN
+//   - opts: 筛选与分页选项
+//
+// 返回:
+//   - ReviewResult: 所有已抓取页面的评论及分页信息
+//   - error: 错误信息，如果没有错误则为nil
+func (s *AmazonSpider) FetchReviews(ctx context.Context, asin string, opts ReviewOptions) (ReviewResult, error) {
+	asin = strings.ToUpper(strings.TrimSpace(asin))
+	if !IsValidASIN(asin) {
+		return ReviewResult{}, fmt.Errorf("❌ 无效的ASIN: %s", asin)
+	}
+
+	startPage := opts.Page
+	if startPage <= 0 {
+		startPage = 1
+	}
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+
+	result := ReviewResult{ASIN: asin}
+	for page := startPage; page < startPage+maxPages; page++ {
+		var reviewPage ReviewPage
+		err := s.withRetry(ctx, func() (err error) {
+			reviewPage, err = s.fetchReviewPage(ctx, asin, opts, page)
+			return err
+		})
+		if err != nil {
+			// 已抓取到部分结果时一并返回，方便调用方保留进度
+			return result, fmt.Errorf("❌ 抓取第%d页评论失败: %w", page, err)
+		}
+
+		result.Reviews = append(result.Reviews, reviewPage.Reviews...)
+		result.LastPage = page
+		result.HasNextPage = reviewPage.HasNextPage
+
+		if !reviewPage.HasNextPage {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// 🔧 fetchReviewPage 请求并解析单页评论
+func (s *AmazonSpider) fetchReviewPage(ctx context.Context, asin string, opts ReviewOptions, page int) (ReviewPage, error) {
+	star := opts.Star
+	if star == "" {
+		star = ReviewStarAll
+	}
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = ReviewSortHelpful
+	}
+	reviewerType := "all_reviews"
+	if opts.VerifiedOnly {
+		reviewerType = "avp_only_reviews"
+	}
+
+	mp := s.marketplaceOr(opts.Marketplace)
+	resp, err := s.execute(ctx, "", func(req *resty.Request) (*resty.Response, error) {
+		return req.
+			SetHeaders(mp.LocaleHeaders()).
+			SetQueryParams(map[string]string{
+				"ie":           "UTF8",
+				"filterByStar": string(star),
+				"sortBy":       string(sortBy),
+				"reviewerType": reviewerType,
+				"pageNumber":   strconv.Itoa(page),
+			}).
+			Get(mp.ReviewsURL(asin))
+	})
+	if err != nil {
+		return ReviewPage{}, fmt.Errorf("请求失败: %w", err)
+	}
+
+	if err := checkPage(resp); err != nil {
+		return ReviewPage{}, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return ReviewPage{}, errs.NewHTTPError(PlatformName, resp.Request.URL, resp.StatusCode(), resp.Body())
+	}
+
+	return s.extractor.GetReviewPage(string(resp.Body())), nil
+}
+
+// GetReviewPage 解析评论页 (公开方法)
+func (e *AmazonExtractor) GetReviewPage(text string) ReviewPage {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return ReviewPage{}
+	}
+
+	videoRule := e.Rules().Product.Videos
+	var reviews []Review
+	doc.Find(`div[data-hook="review"]`).Each(func(i int, item *goquery.Selection) {
+		review := Review{
+			ID:       item.AttrOr("id", ""),
+			Author:   e.firstText(item, ".a-profile-name"),
+			Title:    e.reviewTitle(item),
+			Body:     e.firstText(item, `span[data-hook="review-body"] span`, `span[data-hook="review-body"]`),
+			DateText: e.firstText(item, `span[data-hook="review-date"]`),
+			Verified: item.Find(`span[data-hook="avp-badge"], span[data-hook="avp-badge-linkless"]`).Length() > 0,
+			Videos:   e.getReviewVideos(item, videoRule),
+		}
+
+		ratingText := e.firstText(item, `i[data-hook="review-star-rating"] .a-icon-alt`, `i[data-hook="cmps-review-star-rating"] .a-icon-alt`)
+		review.Rating = parseRating(ratingText, Marketplace{})
+		review.Country, review.Date = parseReviewDate(review.DateText)
+
+		helpfulText := e.firstText(item, `span[data-hook="helpful-vote-statement"]`)
+		if match := helpfulVotesPattern.FindStringSubmatch(helpfulText); len(match) == 2 {
+			if n := digitsToInt(match[1]); n > 0 {
+				review.HelpfulVotes = n
+			} else {
+				review.HelpfulVotes = 1
+			}
+		}
+
+		item.Find(`img[data-hook="review-image-tile"]`).Each(func(i int, img *goquery.Selection) {
+			if src := img.AttrOr("src", ""); src != "" {
+				review.Images = append(review.Images, src)
+			}
+		})
+
+		reviews = append(reviews, review)
+	})
+
+	next := doc.Find("ul.a-pagination li.a-last")
+	hasNext := next.Length() > 0 && !next.HasClass("a-disabled")
+
+	return ReviewPage{
+		Reviews:     reviews,
+		HasNextPage: hasNext,
+	}
+}
+
+// reviewTitle 获取评论标题 (私有方法)
+// 标题链接中包含星级文本，需要排除 .a-icon-alt
+func (e *AmazonExtractor) reviewTitle(item *goquery.Selection) string {
+	title := item.Find(`[data-hook="review-title"]`).First().Clone()
+	title.Find(".a-icon-alt, .a-letter-space").Remove()
+	return strings.Join(strings.Fields(title.Text()), " ")
+}
+
+// parseReviewDate 解析评论日期行中的国家和日期，无法识别的写法返回空国家和nil (私有方法)
+func parseReviewDate(text string) (string, *time.Time) {
+	for _, format := range reviewDateFormats {
+		match := format.pattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		country := match[format.pattern.SubexpIndex("country")]
+		dateText := match[format.pattern.SubexpIndex("date")]
+		if format.months != nil {
+			dateText = format.months.Replace(strings.ToLower(dateText))
+		}
+		for _, layout := range format.layouts {
+			if date, err := time.Parse(layout, dateText); err == nil {
+				return country, &date
+			}
+		}
+		return country, nil
+	}
+	return "", nil
+}
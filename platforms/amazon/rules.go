@@ -0,0 +1,266 @@
+package amazon
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RulesSchema 当前支持的规则文件格式版本
+const RulesSchema = 1
+
+//go:embed rules/default.json
+var defaultRulesJSON []byte
+
+// defaultRules 内置默认规则，未调用 SetRules 时使用
+var defaultRules = mustParseDefaultRules()
+
+// 📐 Rules 页面解析规则
+//
+// 选择器（按顺序回退）、正则和后处理从规则文件加载，页面改版时更新规则文件即可，无需发布新版本。
+// 内置默认规则见 rules/default.json；Rules 传给 SetRules 后不应再修改，需要调整时创建新的 Rules
+type Rules struct {
+	Schema  int          `json:"schema"`  // 规则文件格式版本，必须为 RulesSchema
+	Version string       `json:"version"` // 规则版本，如 "2026.10.1"，便于排查使用的是哪份规则
+	Product ProductRules `json:"product"` // 商品详情页
+	Offers  OfferRules   `json:"offers"`  // 报价列表（AOD）
+}
+
+// ProductRules 商品详情页解析规则，字段与 ProductResult 对应
+type ProductRules struct {
+	Title             TextRule      `json:"title"`
+	Desc              TextRule      `json:"desc"`
+	Feature           TextRule      `json:"feature"`
+	FeatureBullets    TextRule      `json:"feature_bullets"` // 每个匹配的元素为一条
+	APlusDesc         TextRule      `json:"aplus_desc"`
+	ProductParameters TextRule      `json:"product_parameters"`
+	ProductDesc       TextRule      `json:"product_desc"`
+	Discount          TextRule      `json:"discount"`
+	Price             TextRule      `json:"price"`
+	Specs             SpecRule      `json:"specs"`
+	Images            ImageRule     `json:"images"`
+	Videos            VideoRule     `json:"videos"`
+	Variations        VariationRule `json:"variations"`
+	Rating            RatingRule    `json:"rating"`
+	SalesRanks        SalesRankRule `json:"sales_ranks"`
+	Breadcrumbs       AttrRule      `json:"breadcrumbs"` // 类目面包屑中的链接，node 参数为类目ID
+}
+
+// TextRule 文本字段规则
+//
+// 依次尝试 Selectors，使用第一个结果非空的选择器；文本去除首尾空白后依次经过 Post 中的后处理，
+// 设置了 Pattern 时再取正则的第一个分组（没有分组时取整个匹配）
+type TextRule struct {
+	Selectors []string `json:"selectors"`         // CSS选择器，按顺序回退
+	Post      []string `json:"post,omitempty"`    // 后处理，可选值见 textProcessors
+	Pattern   string   `json:"pattern,omitempty"` // 提取文本的正则（可选）
+}
+
+// SpecRule 规格参数规则
+type SpecRule struct {
+	Rows  []string `json:"rows"`  // 表格布局的行（th/td 或前两个td），合并为一个选择器按页面顺序读取
+	Items []string `json:"items"` // 列表布局的条目，Name 匹配的元素为名称，其余文本为值
+	Name  string   `json:"name"`  // 列表条目中的名称元素
+}
+
+// ImageRule 图片规则
+type ImageRule struct {
+	Patterns      []string `json:"patterns"`       // 页面脚本中的图片JSON数组（第一个分组），按顺序尝试
+	Fields        []string `json:"fields"`         // 每张图片依次取的URL字段，如 hiRes、large
+	FallbackScope string   `json:"fallback_scope"` // 备用方法：缩小查找范围的正则（第一个分组）
+	FallbackURL   string   `json:"fallback_url"`   // 备用方法：图片URL（第一个分组）
+}
+
+// VideoRule 视频规则
+type VideoRule struct {
+	APlus        string     `json:"aplus"`         // A+ 区域选择器
+	APlusPattern string     `json:"aplus_pattern"` // A+ 区域HTML中的视频URL
+	JSONPatterns []string   `json:"json_patterns"` // 页面脚本中的视频JSON（第一个分组），读取 videos[].url
+	JSONPrefix   string     `json:"json_prefix"`   // 视频JSON必须以此开头
+	Elements     []AttrRule `json:"elements"`      // 在属性中保存视频URL的元素（视频轮播）
+	Reviews      []AttrRule `json:"reviews"`       // 评论中的视频元素，商品详情页和评论页共用
+	StreamID     string     `json:"stream_id"`     // m3u8 URL中的视频ID（第一个分组），匹配不到的m3u8被忽略
+}
+
+// VariationRule 变体选项（twister）规则
+//
+// 维度和子ASIN来自页面脚本中的 dimensionToAsinMap 等数据，价格、是否有货和图片来自变体选项元素
+type VariationRule struct {
+	Swatches    []string `json:"swatches"`    // 变体选项元素，合并为一个选择器按页面顺序读取
+	ASINAttrs   []string `json:"asin_attrs"`  // 选项元素上保存ASIN（或商品链接）的属性，按顺序尝试
+	Price       TextRule `json:"price"`       // 选项中的价格，在选项元素内查找
+	Unavailable string   `json:"unavailable"` // 选项元素或其子元素匹配时表示无货
+	Image       AttrRule `json:"image"`       // 选项中的图片
+}
+
+// RatingRule 评分规则
+type RatingRule struct {
+	Average   TextRule `json:"average"`   // 平均评分文本，如 "4.5 out of 5 stars"、"5つ星のうち4.3"，按站点格式取出评分
+	Count     TextRule `json:"count"`     // 评分总数，千分位分隔符会被去除
+	Histogram []string `json:"histogram"` // 星级分布的每一行，行文本中第一个数字为星级，"%" 前的数字为百分比
+}
+
+// SalesRankRule 畅销排名（Best Sellers Rank）规则
+//
+// 在规格行中找到标签匹配的一行，去掉标签后 Entries 匹配的每个元素为一条排名，
+// 其余文本（详情条目布局中的大类排名）作为第一条
+type SalesRankRule struct {
+	Rows        []string `json:"rows"`         // 规格表格行或详情条目，合并为一个选择器按页面顺序读取
+	Labels      []string `json:"labels"`       // 排名行的标签（忽略大小写），如 "Best Sellers Rank"
+	Label       string   `json:"label"`        // 行内的标签元素
+	Entries     string   `json:"entries"`      // 行内每条排名的元素
+	Pattern     string   `json:"pattern"`      // 排名文本：第一个分组为名次，第二个分组为类目名称
+	Link        string   `json:"link"`         // 排名中的排行榜链接
+	NodePattern string   `json:"node_pattern"` // 排行榜链接中的类目ID（第一个分组）
+}
+
+// OfferRules 报价列表（AOD，All Offers Display）解析规则
+//
+// 报价中的字段在每个报价元素内查找
+type OfferRules struct {
+	Pinned     []string `json:"pinned"`      // 购物车（buy box）报价元素
+	Offers     []string `json:"offers"`      // 其他报价元素，合并为一个选择器按页面顺序读取
+	Total      AttrRule `json:"total"`       // 报价总数
+	Price      TextRule `json:"price"`       // 价格
+	Condition  TextRule `json:"condition"`   // 商品状况，如 New、Used - Like New
+	SellerName TextRule `json:"seller_name"` // 卖家名称
+	SellerLink AttrRule `json:"seller_link"` // 卖家链接，seller 参数为卖家ID
+	ShipsFrom  TextRule `json:"ships_from"`  // 发货方
+	Shipping   AttrRule `json:"shipping"`    // 运费文本，如 "$5.99"、"FREE"
+	Delivery   AttrRule `json:"delivery"`    // 预计送达时间
+	Prime      string   `json:"prime"`       // 匹配时表示Prime报价
+	FreeWords  []string `json:"free_words"`  // 运费文本包含其中之一时表示免运费（忽略大小写）
+}
+
+// AttrRule 元素属性规则
+type AttrRule struct {
+	Selector string `json:"selector"`
+	Attr     string `json:"attr"`
+}
+
+// textProcessors 文本后处理
+var textProcessors = map[string]func(string) string{
+	"clean":       cleanText,                                                       // 去除方向标记并合并空白
+	"first_field": func(text string) string { return strings.Split(text, " ")[0] }, // 第一个空格前的内容
+	"money":       findMoneyText,                                                   // 完整的价格文本，如 "1 299,99 €"
+}
+
+// DefaultRules 返回内置默认规则的副本
+func DefaultRules() *Rules {
+	rules := &Rules{}
+	if err := json.Unmarshal(defaultRulesJSON, rules); err != nil {
+		panic(fmt.Sprintf("内置解析规则无效: %v", err))
+	}
+	return rules
+}
+
+// ParseRules 解析规则文件（JSON）
+//
+// 🔧 按字段合并到默认规则上，文件中只需写要修改的规则，例如:
+//
+//	{"version": "custom-1", "product": {"title": {"selectors": ["#productTitle", "#title"]}}}
+func ParseRules(data []byte) (*Rules, error) {
+	rules := DefaultRules()
+	if err := json.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("解析规则文件失败: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadRulesFile 从文件加载规则，见 ParseRules
+func LoadRulesFile(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取规则文件失败: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// Validate 检查规则格式版本、正则和后处理是否有效
+func (r *Rules) Validate() error {
+	if r.Schema != RulesSchema {
+		return fmt.Errorf("不支持的规则格式版本: %d（当前支持 %d）", r.Schema, RulesSchema)
+	}
+
+	p := r.Product
+	texts := map[string]TextRule{
+		"title": p.Title, "desc": p.Desc, "feature": p.Feature, "feature_bullets": p.FeatureBullets,
+		"aplus_desc": p.APlusDesc, "product_parameters": p.ProductParameters, "product_desc": p.ProductDesc,
+		"discount": p.Discount, "price": p.Price, "variations.price": p.Variations.Price,
+		"rating.average": p.Rating.Average, "rating.count": p.Rating.Count,
+		"offers.price": r.Offers.Price, "offers.condition": r.Offers.Condition,
+		"offers.seller_name": r.Offers.SellerName, "offers.ships_from": r.Offers.ShipsFrom,
+	}
+	for name, rule := range texts {
+		for _, post := range rule.Post {
+			if textProcessors[post] == nil {
+				return fmt.Errorf("规则 %s: 未知的后处理 %q", name, post)
+			}
+		}
+		if err := checkPatterns(name, rule.Pattern); err != nil {
+			return err
+		}
+	}
+
+	if err := checkPatterns("images", p.Images.Patterns...); err != nil {
+		return err
+	}
+	if err := checkPatterns("images", p.Images.FallbackScope, p.Images.FallbackURL); err != nil {
+		return err
+	}
+	if err := checkPatterns("videos", p.Videos.JSONPatterns...); err != nil {
+		return err
+	}
+	if err := checkPatterns("sales_ranks", p.SalesRanks.Pattern, p.SalesRanks.NodePattern); err != nil {
+		return err
+	}
+	return checkPatterns("videos", p.Videos.APlusPattern, p.Videos.StreamID)
+}
+
+// checkPatterns 检查正则是否有效，空字符串表示未设置
+func checkPatterns(name string, patterns ...string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("规则 %s: 无效的正则 %q: %w", name, pattern, err)
+		}
+	}
+	return nil
+}
+
+// mustParseDefaultRules 解析内置默认规则
+func mustParseDefaultRules() *Rules {
+	rules := DefaultRules()
+	if err := rules.Validate(); err != nil {
+		panic(fmt.Sprintf("内置解析规则无效: %v", err))
+	}
+	return rules
+}
+
+// rulePatterns 已编译的规则正则，规则热更新后旧的正则仍可复用
+var rulePatterns sync.Map
+
+// rulePattern 返回编译后的正则，空字符串或无效的正则返回nil（Validate 会提前报告无效的正则）
+func rulePattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+	if re, ok := rulePatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	rulePatterns.Store(pattern, re)
+	return re
+}
@@ -0,0 +1,190 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-resty/resty/v2"
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// 🔍 SearchProducts 按关键词搜索亚马逊商品
+//
+// 抓取亚马逊 /s 搜索结果页并解析为商品列表，支持从指定页开始连续翻页
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求的生命周期
+//   - keyword: 搜索关键词
+//   - opts: 分页选项
+//
+// 返回:
+//   - KeywordSearchResult: 所有已抓取页面的商品及分页信息
+//   - error: 错误信息，如果没有错误则为nil
+func (s *AmazonSpider) SearchProducts(ctx context.Context, keyword string, opts SearchOptions) (KeywordSearchResult, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return KeywordSearchResult{}, fmt.Errorf("❌ 搜索关键词不能为空")
+	}
+
+	startPage := opts.Page
+	if startPage <= 0 {
+		startPage = 1
+	}
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+
+	result := KeywordSearchResult{Keyword: keyword}
+	for page := startPage; page < startPage+maxPages; page++ {
+		var searchPage KeywordSearchPage
+		err := s.withRetry(ctx, func() (err error) {
+			searchPage, err = s.fetchSearchPage(ctx, s.marketplaceOr(opts.Marketplace), keyword, page)
+			return err
+		})
+		if err != nil {
+			// 已抓取到部分结果时一并返回，方便调用方保留进度
+			return result, fmt.Errorf("❌ 搜索第%d页失败: %w", page, err)
+		}
+
+		products := searchPage.Products
+		if opts.PageSize > 0 && len(products) > opts.PageSize {
+			products = products[:opts.PageSize]
+		}
+
+		result.Products = append(result.Products, products...)
+		result.LastPage = page
+		result.TotalPages = searchPage.TotalPages
+		result.HasNextPage = searchPage.HasNextPage
+
+		if !searchPage.HasNextPage {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// 🔧 fetchSearchPage 请求并解析单页搜索结果
+func (s *AmazonSpider) fetchSearchPage(ctx context.Context, mp Marketplace, keyword string, page int) (KeywordSearchPage, error) {
+	resp, err := s.execute(ctx, "", func(req *resty.Request) (*resty.Response, error) {
+		return req.
+			SetHeaders(mp.LocaleHeaders()).
+			SetQueryParam("k", keyword).
+			SetQueryParam("page", strconv.Itoa(page)).
+			Get(mp.SearchURL())
+	})
+	if err != nil {
+		return KeywordSearchPage{}, fmt.Errorf("请求失败: %w", err)
+	}
+
+	if err := checkPage(resp); err != nil {
+		return KeywordSearchPage{}, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return KeywordSearchPage{}, errs.NewHTTPError(PlatformName, resp.Request.URL, resp.StatusCode(), resp.Body())
+	}
+
+	searchPage := s.extractor.GetSearchPage(mp.SearchURL(), string(resp.Body()))
+	for i := range searchPage.Products {
+		searchPage.Products[i].Page = page
+	}
+	return searchPage, nil
+}
+
+// GetSearchPage 解析搜索结果页 (公开方法)
+// pageURL 用于识别站点以解析价格、评分和评论数，未知域名按美国站处理
+func (e *AmazonExtractor) GetSearchPage(pageURL, text string) KeywordSearchPage {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return KeywordSearchPage{}
+	}
+
+	mp, known := MarketplaceByDomain(pageURL)
+	if !known {
+		mp = MarketplaceUS
+	}
+
+	var products []SearchProduct
+	doc.Find(`div[data-component-type="s-search-result"]`).Each(func(i int, item *goquery.Selection) {
+		asin := strings.TrimSpace(item.AttrOr("data-asin", ""))
+		if asin == "" {
+			return
+		}
+
+		product := SearchProduct{
+			ASIN:         asin,
+			Title:        e.firstText(item, "h2 span", "h2"),
+			LinkURL:      mp.ProductURL(asin),
+			PriceText:    e.firstText(item, ".a-price:not(.a-text-price) .a-offscreen", ".a-price .a-offscreen"),
+			Sponsored:    item.Find(".puis-sponsored-label-text, .s-sponsored-label-text").Length() > 0,
+			Badge:        e.firstText(item, ".a-badge-text", ".a-badge-label-inner"),
+			ThumbnailURL: item.Find("img.s-image").First().AttrOr("src", ""),
+			Position:     len(products) + 1,
+		}
+
+		if money, ok := ParseMoney(product.PriceText, mp); ok {
+			price := money.Float64()
+			product.Price, product.PriceMoney = &price, &money
+		}
+
+		product.Rating = parseRating(e.firstText(item, "i.a-icon-star-small .a-icon-alt", "i.a-icon-star .a-icon-alt", ".a-icon-alt"), mp)
+
+		reviewText := e.firstText(item,
+			`[data-csa-c-content-id="alf-customer-ratings-count-component"] .a-size-base`,
+			`a[href*="customerReviews"] .a-size-base`,
+			".a-size-base.s-underline-text")
+		product.ReviewCount = digitsToInt(moneyNumberPattern.FindString(reviewText))
+
+		products = append(products, product)
+	})
+
+	// 📄 分页信息
+	totalPages := 0
+	doc.Find(".s-pagination-item").Each(func(i int, item *goquery.Selection) {
+		if n, err := strconv.Atoi(strings.TrimSpace(item.Text())); err == nil && n > totalPages {
+			totalPages = n
+		}
+	})
+	next := doc.Find(".s-pagination-next")
+	hasNext := next.Length() > 0 && !next.HasClass("s-pagination-disabled")
+
+	return KeywordSearchPage{
+		Products:    products,
+		TotalPages:  totalPages,
+		HasNextPage: hasNext,
+	}
+}
+
+// firstText 依次尝试多个选择器，返回第一个非空文本 (私有方法)
+func (e *AmazonExtractor) firstText(sel *goquery.Selection, selectors ...string) string {
+	for _, selector := range selectors {
+		text := strings.TrimSpace(sel.Find(selector).First().Text())
+		if text != "" {
+			return strings.Join(strings.Fields(text), " ")
+		}
+	}
+	return ""
+}
+
+// parseRating 按站点格式解析评分文本，如 "4.2 out of 5 stars"、"4,5 von 5 Sternen"、"5つ星のうち4.2" (私有方法)
+// 评分带一位小数，优先取带分隔符的数字，避免把日本站开头的满分 "5" 当作评分
+func parseRating(text string, mp Marketplace) float64 {
+	numbers := moneyNumberPattern.FindAllString(text, -1)
+	if len(numbers) == 0 {
+		return 0
+	}
+	number := numbers[0]
+	for _, n := range numbers {
+		if strings.ContainsAny(n, ".,") {
+			number = n
+			break
+		}
+	}
+	rating, _ := parseDecimal(number, mp)
+	return rating
+}
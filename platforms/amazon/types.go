@@ -1,28 +1,86 @@
 package amazon
 
+import (
+	"strings"
+	"time"
+)
+
 // ProductDetail 产品详细信息结构
 type ProductDetail struct {
-	Title             string  `json:"title"`
-	ByDesc            string  `json:"by_desc"`
-	Feature           string  `json:"feature"`
-	BucketdividerDesc string  `json:"bucketdivider_desc"`
-	ProductParameters string  `json:"product_parameters"`
-	ProductDesc       string  `json:"product_desc"`
-	ProductDiscount   *string `json:"product_discount"`
-	ProductPrice      *string `json:"product_price"`
-	Language          string  `json:"language"`
+	Title             string        `json:"title"`
+	ByDesc            string        `json:"by_desc"`
+	Feature           string        `json:"feature"`
+	FeatureBullets    []string      `json:"feature_bullets"`
+	BucketdividerDesc string        `json:"bucketdivider_desc"`
+	ProductParameters string        `json:"product_parameters"`
+	Specs             []ProductSpec `json:"specs"`
+	ProductDesc       string        `json:"product_desc"`
+	ProductDiscount   *string       `json:"product_discount"`
+	ProductPrice      *string       `json:"product_price"`
+	ProductPriceMoney *Money        `json:"product_price_money"`
+	Language          string        `json:"language"`
+}
+
+// ProductSpec 商品规格参数（技术细节/商品信息表中的一行）
+type ProductSpec struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // ProductResult 产品结果结构
 type ProductResult struct {
-	LinkURL  string   `json:"link_url"`
-	Title    string   `json:"title"`
-	Desc     string   `json:"desc"`
-	Language string   `json:"language"`
-	Images   []string `json:"images"`
-	Videos   []string `json:"videos"`
-	Price    *string  `json:"price"`
-	Discount *string  `json:"discount"`
+	LinkURL           string        `json:"link_url"`
+	ASIN              string        `json:"asin"`
+	Title             string        `json:"title"`
+	Desc              string        `json:"desc"`
+	Language          string        `json:"language"`
+	Images            []string      `json:"images"`
+	Videos            []string      `json:"videos"`
+	Price             *string       `json:"price"`
+	Discount          *string       `json:"discount"`
+	PriceMoney        *Money        `json:"price_money"` // 价格金额（含货币代码），Price 为格式化后的文本
+	Feature           string        `json:"feature"`
+	FeatureBullets    []string      `json:"feature_bullets"`
+	APlusDesc         string        `json:"aplus_desc"`
+	ProductParameters string        `json:"product_parameters"`
+	Specs             []ProductSpec `json:"specs"`
+	ProductDesc       string        `json:"product_desc"`
+	Rating            *Rating       `json:"rating"`      // 评分汇总，页面没有评分时为nil
+	SalesRanks        []SalesRank   `json:"sales_ranks"` // 畅销排名（Best Sellers Rank），大类排名在前
+	Breadcrumbs       []Breadcrumb  `json:"breadcrumbs"` // 类目面包屑，从大类到商品所在的类目
+}
+
+// Rating 商品评分汇总
+type Rating struct {
+	Average   float64     `json:"average"`   // 平均评分，如 4.5
+	Count     int         `json:"count"`     // 评分总数
+	Histogram map[int]int `json:"histogram"` // 星级 → 百分比，如 {5: 67, 4: 18}
+}
+
+// SalesRank 畅销排名
+type SalesRank struct {
+	Rank         int      `json:"rank"`          // 名次
+	Category     string   `json:"category"`      // 类目名称
+	CategoryID   string   `json:"category_id"`   // 类目ID（browse node），大类排名通常没有
+	CategoryPath []string `json:"category_path"` // 类目路径，类目出现在面包屑中时为完整路径，否则只有类目本身
+	LinkURL      string   `json:"link_url"`      // 排行榜链接
+}
+
+// Breadcrumb 类目面包屑节点
+type Breadcrumb struct {
+	Name    string `json:"name"`
+	NodeID  string `json:"node_id"` // 类目ID（browse node）
+	LinkURL string `json:"link_url"`
+}
+
+// Spec 按名称查找规格参数（忽略大小写），不存在时返回空字符串
+func (r ProductResult) Spec(name string) string {
+	for _, spec := range r.Specs {
+		if strings.EqualFold(spec.Name, name) {
+			return spec.Value
+		}
+	}
+	return ""
 }
 
 // ImageSearchProduct 图片搜索商品结构
@@ -44,6 +102,9 @@ type ImageSearchProduct struct {
 	ColorSwatches                []interface{}      `json:"colorSwatches"`
 	TwisterVariations            []TwisterVariation `json:"twisterVariations"`
 	LinkURL                      string             `json:"link_url"`
+
+	PriceMoney     *Money `json:"price_money,omitempty"`      // 由 Price 按站点格式解析
+	ListPriceMoney *Money `json:"list_price_money,omitempty"` // 由 ListPrice 按站点格式解析
 }
 
 // TwisterVariation 变体信息
@@ -61,3 +122,199 @@ type StyleSnapResponse struct {
 type SearchResult struct {
 	BBXASINMetadataList []ImageSearchProduct `json:"bbxAsinMetadataList"`
 }
+
+// SearchOptions 关键词搜索选项
+type SearchOptions struct {
+	Page     int // 起始页码，默认1
+	MaxPages int // 最多抓取的页数，默认1
+	PageSize int // 每页最多保留的商品数量，0表示不限制
+
+	Marketplace Marketplace // 搜索站点，为空时使用爬虫的默认站点
+}
+
+// SearchProduct 关键词搜索结果中的商品
+type SearchProduct struct {
+	ASIN         string   `json:"asin"`
+	Title        string   `json:"title"`
+	LinkURL      string   `json:"link_url"`
+	PriceText    string   `json:"price_text"`
+	Price        *float64 `json:"price"`                 // 以主货币单位表示的价格，由 PriceMoney 换算
+	PriceMoney   *Money   `json:"price_money,omitempty"` // 由 PriceText 按站点格式解析
+	Rating       float64  `json:"rating"`
+	ReviewCount  int      `json:"review_count"`
+	Sponsored    bool     `json:"sponsored"`
+	Badge        string   `json:"badge"`
+	ThumbnailURL string   `json:"thumbnail_url"`
+	Page         int      `json:"page"`
+	Position     int      `json:"position"`
+}
+
+// KeywordSearchPage 单页关键词搜索结果
+type KeywordSearchPage struct {
+	Products    []SearchProduct `json:"products"`
+	TotalPages  int             `json:"total_pages"`
+	HasNextPage bool            `json:"has_next_page"`
+}
+
+// KeywordSearchResult 关键词搜索结果
+type KeywordSearchResult struct {
+	Keyword     string          `json:"keyword"`
+	Products    []SearchProduct `json:"products"`
+	LastPage    int             `json:"last_page"`
+	TotalPages  int             `json:"total_pages"`
+	HasNextPage bool            `json:"has_next_page"`
+}
+
+// ReviewStarFilter 评论星级筛选
+type ReviewStarFilter string
+
+// 评论星级筛选取值
+const (
+	ReviewStarAll      ReviewStarFilter = "all_stars"
+	ReviewStarFive     ReviewStarFilter = "five_star"
+	ReviewStarFour     ReviewStarFilter = "four_star"
+	ReviewStarThree    ReviewStarFilter = "three_star"
+	ReviewStarTwo      ReviewStarFilter = "two_star"
+	ReviewStarOne      ReviewStarFilter = "one_star"
+	ReviewStarPositive ReviewStarFilter = "positive"
+	ReviewStarCritical ReviewStarFilter = "critical"
+)
+
+// ReviewSort 评论排序方式
+type ReviewSort string
+
+// 评论排序取值
+const (
+	ReviewSortHelpful ReviewSort = "helpful"
+	ReviewSortRecent  ReviewSort = "recent"
+)
+
+// ReviewOptions 评论抓取选项
+type ReviewOptions struct {
+	Star         ReviewStarFilter // 星级筛选，默认全部
+	SortBy       ReviewSort       // 排序方式，默认最有帮助
+	VerifiedOnly bool             // 只看已验证购买
+	Page         int              // 起始页码，默认1
+	MaxPages     int              // 最多抓取的页数，默认1
+
+	Marketplace Marketplace // 评论站点，为空时使用爬虫的默认站点
+}
+
+// Review 商品评论
+type Review struct {
+	ID           string     `json:"id"`
+	Author       string     `json:"author"`
+	Rating       float64    `json:"rating"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body"`
+	DateText     string     `json:"date_text"`
+	Date         *time.Time `json:"date"`
+	Country      string     `json:"country"`
+	Verified     bool       `json:"verified"`
+	HelpfulVotes int        `json:"helpful_votes"`
+	Images       []string   `json:"images"`
+	Videos       []string   `json:"videos"`
+}
+
+// ReviewPage 单页评论
+type ReviewPage struct {
+	Reviews     []Review `json:"reviews"`
+	HasNextPage bool     `json:"has_next_page"`
+}
+
+// ReviewResult 评论抓取结果
+type ReviewResult struct {
+	ASIN        string   `json:"asin"`
+	Reviews     []Review `json:"reviews"`
+	LastPage    int      `json:"last_page"`
+	HasNextPage bool     `json:"has_next_page"`
+}
+
+// BatchOptions 批量获取商品详情选项
+type BatchOptions struct {
+	Concurrency        int               // 同时进行的请求数，默认 ChunkSize
+	PerHostConcurrency int               // 同一站点同时进行的请求数，0表示不单独限制
+	PerHostInterval    time.Duration     // 同一站点相邻两次请求的最小间隔，0表示不限速
+	Ordered            bool              // 按输入顺序输出结果，默认按完成顺序
+	OnResult           func(BatchResult) // 每完成一个商品调用一次（可选），各次调用不会并发
+}
+
+// BatchResult 批量获取中单个商品的结果
+type BatchResult struct {
+	Index   int           `json:"index"` // 在输入列表中的下标
+	URL     string        `json:"url"`
+	Product ProductResult `json:"product"`
+	Err     error         `json:"-"`
+}
+
+// VariationOptions 商品变体选项
+type VariationOptions struct {
+	FetchDetails bool         // 同时获取每个子ASIN的商品详情
+	Batch        BatchOptions // 获取子商品详情的并发与限速选项
+	Marketplace  Marketplace  // 商品站点，为空时使用爬虫的默认站点
+}
+
+// VariationResult 商品变体（twister）矩阵
+type VariationResult struct {
+	ParentASIN  string               `json:"parent_asin"`
+	CurrentASIN string               `json:"current_asin"` // 页面当前展示的子ASIN
+	Dimensions  []VariationDimension `json:"dimensions"`   // 变体维度，如尺寸、颜色
+	Variations  []Variation          `json:"variations"`   // 每个子ASIN，按维度取值的顺序排列
+}
+
+// VariationDimension 变体维度
+type VariationDimension struct {
+	Key    string   `json:"key"`    // 维度标识，如 size_name、color_name
+	Name   string   `json:"name"`   // 显示名称，如 Size、Color
+	Values []string `json:"values"` // 所有取值
+}
+
+// Variation 子ASIN
+type Variation struct {
+	ASIN      string            `json:"asin"`
+	Values    map[string]string `json:"values"`           // 维度标识 → 取值，如 {"size_name": "M", "color_name": "Black"}
+	Price     *Money            `json:"price"`            // 变体选项上的价格，页面未显示时为nil（获取详情后使用详情页价格）
+	Available *bool             `json:"available"`        // 是否有货，页面未标明时为nil
+	ImageURL  string            `json:"image_url"`        // 变体图片
+	LinkURL   string            `json:"link_url"`         // 子商品链接
+	Detail    *ProductResult    `json:"detail,omitempty"` // 子商品详情，仅 FetchDetails 为true且获取成功时有值
+	Err       error             `json:"-"`                // 获取子商品详情失败的错误
+}
+
+// OfferOptions 报价列表抓取选项
+type OfferOptions struct {
+	MaxPages    int         // 最多抓取的页数，默认1（每页10个报价）
+	Marketplace Marketplace // 商品站点，为空时使用爬虫的默认站点
+}
+
+// Offer 卖家报价
+type Offer struct {
+	SellerName        string `json:"seller_name"`
+	SellerID          string `json:"seller_id"`           // 卖家ID（merchant ID），亚马逊自营时可能为空
+	Condition         string `json:"condition"`           // 商品状况，如 "New"、"Used - Like New"
+	Price             *Money `json:"price"`               // 商品价格，不含运费
+	Shipping          *Money `json:"shipping"`            // 运费，免运费时金额为0，页面未显示时为nil
+	ShipsFrom         string `json:"ships_from"`          // 发货方
+	DeliveryEstimate  string `json:"delivery_estimate"`   // 预计送达时间，如 "Tuesday, October 21"
+	Prime             bool   `json:"prime"`               // Prime报价
+	FulfilledByAmazon bool   `json:"fulfilled_by_amazon"` // 亚马逊配送（FBA）
+	SoldByAmazon      bool   `json:"sold_by_amazon"`      // 亚马逊自营
+	BuyBoxWinner      bool   `json:"buy_box_winner"`      // 购物车（buy box）报价
+}
+
+// OfferPage 报价列表单页结果
+type OfferPage struct {
+	BuyBox      *Offer  `json:"buy_box"`      // 购物车报价，只在第一页出现，没有购物车时为nil
+	Offers      []Offer `json:"offers"`       // 其他报价
+	TotalOffers int     `json:"total_offers"` // 报价总数（含购物车报价），页面未显示时为0
+}
+
+// OfferResult 商品报价列表
+type OfferResult struct {
+	ASIN        string  `json:"asin"`
+	BuyBox      *Offer  `json:"buy_box"`      // 购物车报价，没有购物车时为nil
+	Offers      []Offer `json:"offers"`       // 所有已抓取的报价，购物车报价排在最前
+	TotalOffers int     `json:"total_offers"` // 报价总数（含购物车报价）
+	LastPage    int     `json:"last_page"`    // 最后抓取的页码
+	HasNextPage bool    `json:"has_next_page"`
+}
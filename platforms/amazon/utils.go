@@ -2,7 +2,6 @@ package amazon
 
 import (
 	"regexp"
-	"strconv"
 	"strings"
 )
 
@@ -85,7 +84,7 @@ func (a *AmazonUtil) FindValue(searchValue, searchKey, valueKey string) string {
 
 // MatchCurrency 在给定的文本中使用正则表达式搜索货币单位
 func (a *AmazonUtil) MatchCurrency(text string) string {
-	currencyRegex := regexp.MustCompile(`(\$|￥|€|₹|Rp|¥|₩|RM|रू|฿|₫)`)
+	currencyRegex := regexp.MustCompile(`(\$|￥|€|£|₹|Rp|¥|₩|RM|रू|฿|₫)`)
 	matches := currencyRegex.FindStringSubmatch(text)
 	if len(matches) > 1 {
 		return matches[1]
@@ -100,14 +99,11 @@ func (a *AmazonUtil) FindValueForCurrency(text, valueKey string) string {
 }
 
 // ExtractPrice 从字符串中提取价格并转为浮点数
+// 🌍 自动区分小数点与千分位，"$1,299.99" 和 "1.299,99 €" 均解析为 1299.99；
+// 已知站点时使用 ParseMoney 获得更准确的结果
 func (a *AmazonUtil) ExtractPrice(priceStr string) *float64 {
-	pattern := regexp.MustCompile(`\d+(,\d{3})*(\.\d+)?`)
-	match := pattern.FindString(priceStr)
-	if match != "" {
-		priceNoComma := strings.ReplaceAll(match, ",", "")
-		if price, err := strconv.ParseFloat(priceNoComma, 64); err == nil {
-			return &price
-		}
+	if price, ok := parseDecimal(priceStr, Marketplace{}); ok {
+		return &price
 	}
 	return nil
 }
@@ -0,0 +1,320 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 🎨 FetchVariations 获取商品的变体（twister）矩阵
+//
+// 请求商品详情页，解析变体维度（尺寸、颜色、款式等）以及每个子ASIN的取值、价格、是否有货和图片，
+// 传入父ASIN或任意一个子ASIN均可。opts.FetchDetails 为true时再按 opts.Batch 批量获取每个子ASIN的商品详情，
+// 单个子商品失败不影响整体结果，错误保存在对应 Variation 的 Err 中
+//
+// 参数:
+//   - ctx: 上下文，用于控制请求的生命周期
+//   - asin: 父ASIN或子ASIN
+//   - opts: 站点与子商品详情选项
+//
+// 返回:
+//   - VariationResult: 变体矩阵，商品没有变体时 Variations 为空
+//   - error: 错误信息，如果没有错误则为nil
+func (s *AmazonSpider) FetchVariations(ctx context.Context, asin string, opts VariationOptions) (VariationResult, error) {
+	asin = strings.ToUpper(strings.TrimSpace(asin))
+	if !IsValidASIN(asin) {
+		return VariationResult{}, fmt.Errorf("❌ 无效的ASIN: %s", asin)
+	}
+	mp := s.marketplaceOr(opts.Marketplace)
+	productURL := mp.ProductURL(asin)
+
+	// 🔁 超时、网络中断、429/5xx 按重试策略重试
+	var body string
+	err := s.withRetry(ctx, func() (err error) {
+		body, err = s.fetchProductHTML(ctx, mp, productURL)
+		return err
+	})
+	if err != nil {
+		return VariationResult{}, err
+	}
+
+	result := s.extractor.GetVariations(productURL, body)
+	if result.ParentASIN == "" {
+		result.ParentASIN = asin
+	}
+	if opts.FetchDetails && len(result.Variations) > 0 {
+		s.fetchVariationDetails(ctx, result.Variations, opts.Batch)
+	}
+	return result, nil
+}
+
+// 🔧 fetchVariationDetails 批量获取子商品详情，并补充变体选项上没有的价格和图片
+func (s *AmazonSpider) fetchVariationDetails(ctx context.Context, variations []Variation, opts BatchOptions) {
+	urls := make([]string, len(variations))
+	for i, v := range variations {
+		urls[i] = v.LinkURL
+	}
+
+	products, failures := s.FetchProductDetails(ctx, urls, opts)
+	for i := range variations {
+		v := &variations[i]
+		if failures[i] != nil {
+			v.Err = failures[i]
+			continue
+		}
+		detail := products[i]
+		v.Detail = &detail
+		if v.Price == nil {
+			v.Price = detail.PriceMoney
+		}
+		if v.ImageURL == "" && len(detail.Images) > 0 {
+			v.ImageURL = detail.Images[0]
+		}
+	}
+}
+
+// twisterData 页面脚本中的变体数据
+type twisterData struct {
+	ParentASIN        string
+	CurrentASIN       string
+	Dimensions        []string                            // 维度标识，如 ["size_name", "color_name"]
+	DimensionsDisplay []string                            // 维度显示名称，与 Dimensions 一一对应
+	VariationValues   map[string][]string                 // 维度标识 → 所有取值
+	DimensionToASIN   map[string]string                   // "1_0" → ASIN，数字依次为各维度取值的下标
+	DisplayValues     map[string][]string                 // ASIN → 各维度的取值
+	ColorImages       map[string][]map[string]interface{} // 取值（通常是颜色） → 图片列表
+}
+
+// variationSwatch 变体选项元素中的信息
+type variationSwatch struct {
+	asin      string
+	price     *Money
+	available *bool
+	image     string
+}
+
+// GetVariations 解析商品详情页中的变体矩阵 (公开方法)
+// 子ASIN按维度取值的顺序排列，脚本数据中没有、只出现在变体选项中的子ASIN排在最后
+func (e *AmazonExtractor) GetVariations(url, text string) VariationResult {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return VariationResult{}
+	}
+
+	// 🌍 按链接域名识别站点，未知域名按美国站处理
+	mp, known := MarketplaceByDomain(url)
+	if !known {
+		mp = MarketplaceUS
+	}
+	rules := &e.Rules().Product
+
+	data := parseTwisterData(text)
+	result := VariationResult{ParentASIN: data.ParentASIN, CurrentASIN: data.CurrentASIN}
+	for i, key := range data.Dimensions {
+		dimension := VariationDimension{Key: key, Name: key, Values: data.VariationValues[key]}
+		if i < len(data.DimensionsDisplay) {
+			dimension.Name = data.DimensionsDisplay[i]
+		}
+		result.Dimensions = append(result.Dimensions, dimension)
+	}
+
+	seen := make(map[string]bool)
+	add := func(asin string, values map[string]string) {
+		if asin = strings.ToUpper(asin); !IsValidASIN(asin) || seen[asin] {
+			return
+		}
+		seen[asin] = true
+		result.Variations = append(result.Variations, Variation{ASIN: asin, Values: values, LinkURL: mp.ProductURL(asin)})
+	}
+
+	// 📐 dimensionToAsinMap 给出每个取值组合对应的子ASIN
+	codes := make([]string, 0, len(data.DimensionToASIN))
+	for code := range data.DimensionToASIN {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return lessDimensionCode(codes[i], codes[j]) })
+	for _, code := range codes {
+		asin := data.DimensionToASIN[code]
+		values := make(map[string]string, len(data.Dimensions))
+		for k, index := range strings.Split(code, "_") {
+			if k >= len(data.Dimensions) {
+				break
+			}
+			key := data.Dimensions[k]
+			if n, err := strconv.Atoi(index); err == nil && n >= 0 && n < len(data.VariationValues[key]) {
+				values[key] = data.VariationValues[key][n]
+			} else if display := data.DisplayValues[asin]; k < len(display) {
+				values[key] = display[k]
+			}
+		}
+		add(asin, values)
+	}
+
+	// 没有 dimensionToAsinMap 时使用 dimensionValuesDisplayData
+	if len(codes) == 0 {
+		asins := make([]string, 0, len(data.DisplayValues))
+		for asin := range data.DisplayValues {
+			asins = append(asins, asin)
+		}
+		sort.Strings(asins)
+		for _, asin := range asins {
+			values := make(map[string]string, len(data.Dimensions))
+			for k, value := range data.DisplayValues[asin] {
+				if k < len(data.Dimensions) {
+					values[data.Dimensions[k]] = value
+				}
+			}
+			add(asin, values)
+		}
+	}
+
+	// 🎨 变体选项上的价格、是否有货和图片
+	swatches := e.getSwatches(doc, mp, rules.Variations)
+	bySwatch := make(map[string]variationSwatch, len(swatches))
+	for _, swatch := range swatches {
+		bySwatch[swatch.asin] = swatch
+		add(swatch.asin, nil)
+	}
+	for i := range result.Variations {
+		v := &result.Variations[i]
+		if swatch, ok := bySwatch[v.ASIN]; ok {
+			v.Price, v.Available, v.ImageURL = swatch.price, swatch.available, swatch.image
+		}
+		if v.ImageURL == "" {
+			v.ImageURL = variationImage(data.ColorImages, v.Values, rules.Images.Fields)
+		}
+	}
+	return result
+}
+
+// getSwatches 解析变体选项元素，同一ASIN出现多次时合并各字段的第一个非空值 (私有方法)
+func (e *AmazonExtractor) getSwatches(doc *goquery.Document, mp Marketplace, rule VariationRule) []variationSwatch {
+	if len(rule.Swatches) == 0 {
+		return nil
+	}
+
+	var swatches []variationSwatch
+	index := make(map[string]int)
+	doc.Find(strings.Join(rule.Swatches, ", ")).Each(func(i int, s *goquery.Selection) {
+		asin := swatchASIN(s, rule.ASINAttrs)
+		if asin == "" {
+			return
+		}
+
+		swatch := variationSwatch{asin: asin}
+		if text := e.getRuleTextIn(s, rule.Price); text != "" {
+			if money, ok := ParseMoney(text, mp); ok {
+				swatch.price = &money
+			}
+		}
+		if rule.Unavailable != "" {
+			available := !s.Is(rule.Unavailable) && s.Find(rule.Unavailable).Length() == 0
+			swatch.available = &available
+		}
+		if rule.Image.Selector != "" {
+			swatch.image, _ = s.Find(rule.Image.Selector).First().Attr(rule.Image.Attr)
+		}
+
+		n, ok := index[asin]
+		if !ok {
+			index[asin] = len(swatches)
+			swatches = append(swatches, swatch)
+			return
+		}
+		merged := &swatches[n]
+		if merged.price == nil {
+			merged.price = swatch.price
+		}
+		if merged.available == nil {
+			merged.available = swatch.available
+		}
+		if merged.image == "" {
+			merged.image = swatch.image
+		}
+	})
+	return swatches
+}
+
+// swatchASIN 按顺序读取选项元素上的ASIN属性，属性值也可以是商品链接 (私有方法)
+func swatchASIN(s *goquery.Selection, attrs []string) string {
+	for _, attr := range attrs {
+		if value, ok := s.Attr(attr); ok {
+			if asin, ok := ExtractASIN(value); ok {
+				return asin
+			}
+		}
+	}
+	return ""
+}
+
+// variationImage 按变体的维度取值查找 colorImages 中的第一张图片 (私有方法)
+func variationImage(colorImages map[string][]map[string]interface{}, values map[string]string, fields []string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		images := colorImages[values[key]]
+		if len(images) == 0 {
+			continue
+		}
+		for _, field := range fields {
+			if img, ok := images[0][field].(string); ok && img != "" {
+				return img
+			}
+		}
+	}
+	return ""
+}
+
+// lessDimensionCode 按各维度取值的下标比较 "1_0" 形式的组合 (私有方法)
+func lessDimensionCode(a, b string) bool {
+	as, bs := strings.Split(a, "_"), strings.Split(b, "_")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		ai, aErr := strconv.Atoi(as[i])
+		bi, bErr := strconv.Atoi(bs[i])
+		if aErr != nil || bErr != nil {
+			if as[i] != bs[i] {
+				return as[i] < bs[i]
+			}
+			continue
+		}
+		if ai != bi {
+			return ai < bi
+		}
+	}
+	return len(as) < len(bs)
+}
+
+// parseTwisterData 解析页面脚本（twister-js-init-dpx-data 等）中的变体数据 (私有方法)
+func parseTwisterData(text string) twisterData {
+	var data twisterData
+	scriptValue(text, "parentAsin", &data.ParentASIN)
+	scriptValue(text, "currentAsin", &data.CurrentASIN)
+	scriptValue(text, "dimensions", &data.Dimensions)
+	scriptValue(text, "dimensionsDisplay", &data.DimensionsDisplay)
+	scriptValue(text, "variationValues", &data.VariationValues)
+	scriptValue(text, "dimensionToAsinMap", &data.DimensionToASIN)
+	scriptValue(text, "dimensionValuesDisplayData", &data.DisplayValues)
+	scriptValue(text, "colorImages", &data.ColorImages)
+	return data
+}
+
+// scriptValue 解析页面脚本中 "key" : <JSON值> 形式的值，使用第一个能解析的位置 (私有方法)
+func scriptValue(text, key string, v interface{}) bool {
+	pattern := rulePattern(`"` + regexp.QuoteMeta(key) + `"\s*:\s*`)
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		if json.NewDecoder(strings.NewReader(text[loc[1]:])).Decode(v) == nil {
+			return true
+		}
+	}
+	return false
+}
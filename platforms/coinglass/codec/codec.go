@@ -0,0 +1,293 @@
+// Package codec 实现Coinglass接口的加解密协议
+//
+// 🔐 Coinglass的加密响应由三部分组成：
+//
+//	cache-ts-v2 - ⏰ 客户端请求头中的毫秒时间戳
+//	user        - 🔑 响应头，使用时间戳密钥加密的gzip压缩动态密钥
+//	data        - 📦 响应体字段，使用动态密钥加密的gzip压缩JSON
+//
+// 两层加密均为 AES-128-ECB + PKCS7 填充，密文使用标准Base64编码。
+// 本包不依赖HTTP客户端，可直接解密从HAR文件、代理日志中截获的数据，
+// 也可用于生成加密响应以搭建测试服务。
+package codec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// KeySize AES-128密钥长度（字节）
+const KeySize = 16
+
+// dynamicKeyAlphabet 随机动态密钥使用的字符集
+const dynamicKeyAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// TimestampKey 由cache-ts-v2时间戳生成第一层密钥
+// 📅 规则：Base64编码时间戳字符串后取前16个字符
+func TimestampKey(cacheTsV2 string) ([]byte, error) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(cacheTsV2))
+	if len(encoded) < KeySize {
+		return nil, fmt.Errorf("时间戳过短，无法生成密钥: %q", cacheTsV2)
+	}
+	return []byte(encoded[:KeySize]), nil
+}
+
+// Decrypt 解密一组完整的 (cache-ts-v2, user header, data) 数据
+// 🔓 返回gzip解压后的原始JSON字节
+//
+// 使用示例:
+//
+//	plaintext, err := codec.Decrypt(cacheTsV2, resp.Header.Get("user"), body.Data)
+func Decrypt(cacheTsV2, userHeader, data string) ([]byte, error) {
+	dynamicKey, err := DecryptDynamicKey(cacheTsV2, userHeader)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptPayload(dynamicKey, data)
+}
+
+// Encrypt 使用指定的时间戳和动态密钥加密明文
+// 🔐 返回可直接作为 user 响应头和 data 字段的字符串，是Decrypt的逆过程
+func Encrypt(cacheTsV2, dynamicKey string, plaintext []byte) (userHeader, data string, err error) {
+	userHeader, err = EncryptDynamicKey(cacheTsV2, dynamicKey)
+	if err != nil {
+		return "", "", err
+	}
+	data, err = EncryptPayload(dynamicKey, plaintext)
+	if err != nil {
+		return "", "", err
+	}
+	return userHeader, data, nil
+}
+
+// DecryptDynamicKey 使用时间戳密钥解密user响应头，得到动态密钥
+// 🏷️ 失败时返回 *errs.DecryptError，Stage 为 user header 或 dynamic key
+func DecryptDynamicKey(cacheTsV2, userHeader string) (string, error) {
+	timestampKey, err := TimestampKey(cacheTsV2)
+	if err != nil {
+		return "", decryptErr(errs.StageUserHeader, err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(userHeader)
+	if err != nil {
+		return "", decryptErr(errs.StageUserHeader, fmt.Errorf("解码user header失败: %w", err))
+	}
+
+	compressed, err := DecryptAES(ciphertext, timestampKey)
+	if err != nil {
+		if len(ciphertext)%aes.BlockSize == 0 {
+			// ⏰ 密文格式正确但填充无效，说明时间戳密钥与服务端不一致
+			err = fmt.Errorf("%w: %w", errs.ErrKeyMismatch, err)
+		}
+		return "", decryptErr(errs.StageUserHeader, fmt.Errorf("解密user header失败: %w", err))
+	}
+
+	dynamicKey, err := Gunzip(compressed)
+	if err != nil {
+		return "", decryptErr(errs.StageDynamicKey, fmt.Errorf("解析动态密钥失败: %w", err))
+	}
+	return string(dynamicKey), nil
+}
+
+// EncryptDynamicKey 使用时间戳密钥加密动态密钥，生成user响应头
+func EncryptDynamicKey(cacheTsV2, dynamicKey string) (string, error) {
+	timestampKey, err := TimestampKey(cacheTsV2)
+	if err != nil {
+		return "", err
+	}
+
+	compressed, err := Gzip([]byte(dynamicKey))
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := EncryptAES(compressed, timestampKey)
+	if err != nil {
+		return "", fmt.Errorf("加密user header失败: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptPayload 使用动态密钥解密data字段并解压gzip
+// 🏷️ 失败时返回 *errs.DecryptError，Stage 为 payload 或 gzip
+func DecryptPayload(dynamicKey, data string) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, decryptErr(errs.StagePayload, fmt.Errorf("解码响应数据失败: %w", err))
+	}
+
+	compressed, err := DecryptAES(ciphertext, []byte(dynamicKey))
+	if err != nil {
+		return nil, decryptErr(errs.StagePayload, fmt.Errorf("解密响应数据失败: %w", err))
+	}
+
+	plaintext, err := Gunzip(compressed)
+	if err != nil {
+		return nil, decryptErr(errs.StageGzip, fmt.Errorf("解压最终数据失败: %w", err))
+	}
+	return plaintext, nil
+}
+
+// EncryptPayload 压缩明文并使用动态密钥加密，生成data字段
+func EncryptPayload(dynamicKey string, plaintext []byte) (string, error) {
+	compressed, err := Gzip(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := EncryptAES(compressed, []byte(dynamicKey))
+	if err != nil {
+		return "", fmt.Errorf("加密响应数据失败: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// NewDynamicKey 生成随机的16位动态密钥
+// 🎲 字符集为大小写字母和数字，与线上返回的密钥格式一致
+func NewDynamicKey() (string, error) {
+	buf := make([]byte, KeySize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成随机密钥失败: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = dynamicKeyAlphabet[int(b)%len(dynamicKeyAlphabet)]
+	}
+	return string(buf), nil
+}
+
+// DecryptAES 执行AES-ECB解密
+// 🔐 使用AES算法的ECB模式进行数据解密
+// 🔑 支持128位密钥长度（16字节）
+// 📦 自动处理PKCS7填充的去除
+//
+// AES-ECB特点:
+//
+//	🔒 每个数据块独立加密，相同明文产生相同密文
+//	⚡ 加密速度快，适合大量数据处理
+//	🚫 安全性相对较低，但足够用于API数据传输
+func DecryptAES(ciphertext, key []byte) ([]byte, error) {
+	// 🔍 验证密文长度必须是AES块大小的倍数
+	if len(ciphertext)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("密文长度不是块大小的倍数")
+	}
+
+	// 🔑 验证密钥长度必须为16字节（AES-128）
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("密钥长度必须为16字节")
+	}
+
+	// 🔐 初始化AES解密器
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("初始化AES失败: %w", err)
+	}
+
+	// 🔄 逐块解密数据（ECB模式）
+	decrypted := make([]byte, len(ciphertext))
+	for i := 0; i < len(ciphertext); i += aes.BlockSize {
+		block.Decrypt(decrypted[i:i+aes.BlockSize], ciphertext[i:i+aes.BlockSize])
+	}
+
+	// 📦 去除PKCS7填充
+	return PKCS7Unpad(decrypted)
+}
+
+// EncryptAES 执行AES-ECB加密
+// 🔐 DecryptAES的逆过程：先进行PKCS7填充，再逐块加密
+func EncryptAES(plaintext, key []byte) ([]byte, error) {
+	// 🔑 验证密钥长度必须为16字节（AES-128）
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("密钥长度必须为16字节")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("初始化AES失败: %w", err)
+	}
+
+	padded := PKCS7Pad(plaintext)
+	encrypted := make([]byte, len(padded))
+	for i := 0; i < len(padded); i += aes.BlockSize {
+		block.Encrypt(encrypted[i:i+aes.BlockSize], padded[i:i+aes.BlockSize])
+	}
+	return encrypted, nil
+}
+
+// PKCS7Pad 添加PKCS7填充
+// 📏 数据长度刚好是块大小的倍数时，仍会添加一个完整的填充块
+func PKCS7Pad(data []byte) []byte {
+	paddingLen := aes.BlockSize - len(data)%aes.BlockSize
+	padded := make([]byte, len(data), len(data)+paddingLen)
+	copy(padded, data)
+	return append(padded, bytes.Repeat([]byte{byte(paddingLen)}, paddingLen)...)
+}
+
+// PKCS7Unpad 去除PKCS7填充
+// 📦 PKCS7是一种标准的数据填充方式，用于确保数据长度符合加密算法要求
+// 🔍 填充值等于填充字节的数量，例如填充3个字节则每个字节的值都是3
+// ✂️ 解密后需要去除这些填充字节以获取原始数据
+func PKCS7Unpad(data []byte) ([]byte, error) {
+	length := len(data)
+	if length == 0 {
+		return nil, fmt.Errorf("无效的解密数据长度")
+	}
+
+	// 🔢 获取填充长度（最后一个字节的值）
+	paddingLen := int(data[length-1])
+
+	// 🔍 验证填充长度的合理性
+	if paddingLen == 0 || paddingLen > length || paddingLen > aes.BlockSize {
+		return nil, fmt.Errorf("无效的PKCS7填充")
+	}
+
+	// ✅ 验证填充字节的正确性
+	for i := length - paddingLen; i < length; i++ {
+		if data[i] != byte(paddingLen) {
+			return nil, fmt.Errorf("无效的PKCS7填充字节")
+		}
+	}
+
+	// ✂️ 返回去除填充后的数据
+	return data[:length-paddingLen], nil
+}
+
+// Gzip 使用gzip压缩数据
+func Gzip(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("gzip压缩失败: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("gzip压缩失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Gunzip 解压gzip数据
+// 🗜️ Coinglass使用gzip压缩来减少数据传输量
+func Gunzip(data []byte) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("创建gzip reader失败: %w", err)
+	}
+	defer gzipReader.Close() // 🔒 确保资源释放
+
+	decompressed, err := io.ReadAll(gzipReader)
+	if err != nil {
+		return nil, fmt.Errorf("解压gzip失败: %w", err)
+	}
+	return decompressed, nil
+}
+
+// decryptErr 将解密阶段的错误包装为 *errs.DecryptError
+func decryptErr(stage errs.DecryptStage, err error) error {
+	return &errs.DecryptError{Platform: "coinglass", Stage: stage, Err: err}
+}
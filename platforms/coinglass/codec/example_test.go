@@ -0,0 +1,167 @@
+package codec
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// TestRoundTrip 测试加密后再解密能得到原始数据
+func TestRoundTrip(t *testing.T) {
+	dynamicKey, err := NewDynamicKey()
+	if err != nil {
+		t.Fatalf("❌ 生成动态密钥失败: %v", err)
+	}
+
+	plaintext := []byte(`{"total":664,"pageSize":5,"list":[{"symbol":"BTC","price":65000.5}]}`)
+	userHeader, data, err := Encrypt("1700000000000", dynamicKey, plaintext)
+	if err != nil {
+		t.Fatalf("❌ 加密失败: %v", err)
+	}
+
+	got, err := Decrypt("1700000000000", userHeader, data)
+	if err != nil {
+		t.Fatalf("❌ 解密失败: %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("❌ 解密结果不一致: %s", got)
+	}
+	t.Logf("✅ 往返成功，user header: %s", userHeader)
+}
+
+// TestRoundTripProperty 随机时间戳、密钥和明文的往返属性测试
+func TestRoundTripProperty(t *testing.T) {
+	property := func(ts uint32, plaintext []byte) bool {
+		cacheTsV2 := fmt.Sprintf("17%011d", ts)
+		dynamicKey, err := NewDynamicKey()
+		if err != nil {
+			return false
+		}
+
+		userHeader, data, err := Encrypt(cacheTsV2, dynamicKey, plaintext)
+		if err != nil {
+			return false
+		}
+
+		gotKey, err := DecryptDynamicKey(cacheTsV2, userHeader)
+		if err != nil || gotKey != dynamicKey {
+			return false
+		}
+
+		got, err := DecryptPayload(dynamicKey, data)
+		return err == nil && bytes.Equal(got, plaintext)
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 200}); err != nil {
+		t.Fatalf("❌ 往返属性不成立: %v", err)
+	}
+}
+
+// TestDecryptWrongTimestamp 测试时间戳不匹配时返回可重试的密钥不匹配错误
+func TestDecryptWrongTimestamp(t *testing.T) {
+	userHeader, data, err := Encrypt("1700000000000", "abcdefghijklmnop", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("❌ 加密失败: %v", err)
+	}
+
+	_, err = Decrypt("1800000000000", userHeader, data)
+	var decryptErr *errs.DecryptError
+	if !errors.As(err, &decryptErr) || !errors.Is(err, errs.ErrKeyMismatch) || !decryptErr.Temporary() {
+		t.Fatalf("❌ 期望可重试的密钥不匹配错误，实际: %v", err)
+	}
+
+	// 🚫 user header编码错误不是密钥问题，不可重试
+	_, err = Decrypt("1700000000000", "not-base64!", data)
+	if !errors.As(err, &decryptErr) || errors.Is(err, errs.ErrKeyMismatch) || decryptErr.Temporary() {
+		t.Fatalf("❌ 编码错误不应可重试，实际: %v", err)
+	}
+}
+
+// TestDecryptStages 测试解密错误标明出错阶段
+func TestDecryptStages(t *testing.T) {
+	const cacheTsV2 = "1700000000000"
+	dynamicKey := "abcdefghijklmnop"
+	userHeader, data, err := Encrypt(cacheTsV2, dynamicKey, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("❌ 加密失败: %v", err)
+	}
+
+	// 🔧 使用错误的密钥加密有效数据，使AES解密成功但结果不是gzip
+	notGzip, _ := EncryptAES([]byte("plain"), []byte(dynamicKey))
+	notGzipKey, _ := EncryptAES([]byte("plain"), mustTimestampKey(t, cacheTsV2))
+
+	cases := []struct {
+		name  string
+		err   error
+		stage errs.DecryptStage
+	}{
+		{"user header非Base64", decryptOnly(Decrypt(cacheTsV2, "not-base64!", data)), errs.StageUserHeader},
+		{"动态密钥非gzip", decryptOnly(Decrypt(cacheTsV2, base64.StdEncoding.EncodeToString(notGzipKey), data)), errs.StageDynamicKey},
+		{"data非Base64", decryptOnly(Decrypt(cacheTsV2, userHeader, "not-base64!")), errs.StagePayload},
+		{"data非gzip", decryptOnly(DecryptPayload(dynamicKey, base64.StdEncoding.EncodeToString(notGzip))), errs.StageGzip},
+	}
+	for _, c := range cases {
+		var decryptErr *errs.DecryptError
+		if !errors.As(c.err, &decryptErr) || decryptErr.Stage != c.stage || !errors.Is(c.err, errs.ErrDecrypt) {
+			t.Errorf("❌ %s: 期望阶段 %s，实际 %v", c.name, c.stage, c.err)
+		}
+	}
+}
+
+// decryptOnly 丢弃解密结果，只保留错误
+func decryptOnly(_ []byte, err error) error { return err }
+
+// mustTimestampKey 生成时间戳密钥，失败时终止测试
+func mustTimestampKey(t *testing.T, cacheTsV2 string) []byte {
+	t.Helper()
+	key, err := TimestampKey(cacheTsV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// TestAESBlockBoundaries 测试PKCS7填充在块边界上的行为
+func TestAESBlockBoundaries(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	for _, size := range []int{0, 1, 15, 16, 17, 32} {
+		plaintext := make([]byte, size)
+		if _, err := rand.Read(plaintext); err != nil {
+			t.Fatalf("❌ 生成随机数据失败: %v", err)
+		}
+
+		ciphertext, err := EncryptAES(plaintext, key)
+		if err != nil {
+			t.Fatalf("❌ 长度%d加密失败: %v", size, err)
+		}
+		if len(ciphertext) != (size/16+1)*16 {
+			t.Fatalf("❌ 长度%d密文长度错误: %d", size, len(ciphertext))
+		}
+
+		got, err := DecryptAES(ciphertext, key)
+		if err != nil || !bytes.Equal(got, plaintext) {
+			t.Fatalf("❌ 长度%d往返失败: %v", size, err)
+		}
+	}
+}
+
+// ExampleDecrypt 解密从抓包记录中获取的数据
+func ExampleDecrypt() {
+	// 📦 模拟一份抓包数据：cache-ts-v2请求头、user响应头和data字段
+	cacheTsV2 := "1700000000000"
+	userHeader, data, _ := Encrypt(cacheTsV2, "abcdefghijklmnop", []byte(`["BTC","ETH"]`))
+
+	plaintext, err := Decrypt(cacheTsV2, userHeader, data)
+	if err != nil {
+		fmt.Println("解密失败:", err)
+		return
+	}
+	fmt.Println(string(plaintext))
+	// Output: ["BTC","ETH"]
+}
@@ -0,0 +1,52 @@
+package coinglasstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/xieburoucoco/spider-hub/platforms/coinglass/codec"
+)
+
+// TestServerProtocol 测试模拟服务的加密响应可以被codec解密
+func TestServerProtocol(t *testing.T) {
+	server := NewServer(map[string]interface{}{"/api/spot/support/coin": []string{"BTC", "ETH"}})
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/spot/support/coin", nil)
+	req.Header.Set("cache-ts-v2", "1700000000000")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("❌ 请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("❌ 解析响应失败: %v", err)
+	}
+
+	plaintext, err := codec.Decrypt("1700000000000", resp.Header.Get("user"), body.Data)
+	if err != nil {
+		t.Fatalf("❌ 解密失败: %v", err)
+	}
+	if string(plaintext) != `["BTC","ETH"]` {
+		t.Fatalf("❌ 解密结果不符: %s", plaintext)
+	}
+}
+
+// TestServerMissingTimestamp 测试缺少cache-ts-v2请求头时返回400
+func TestServerMissingTimestamp(t *testing.T) {
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/spot/support/coin")
+	if err != nil {
+		t.Fatalf("❌ 请求失败: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("❌ 期望400，实际: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,19 @@
+package coinglasstest
+
+// DefaultFixtures 返回覆盖全部已知接口的示例数据
+// 📦 数据结构与线上接口保持一致，数值仅用于测试
+func DefaultFixtures() map[string]interface{} {
+	return map[string]interface{}{
+		"/api/home/v2/coinMarkets": `{"total":2,"pageSize":20,"list":[` +
+			`{"symbol":"BTC","symbolLogo":"https://cdn.coinglasscdn.com/static/img/coins/bitcoin-BTC.png","price":65000.5,"priceChangePercent24h":1.25,"marketCap":1280000000000,"openInterest":35000000000,"volUsd":52000000000,"avgFundingRateByOi":0.011645,"avgFundingRateByOiAPR":12.7513},` +
+			`{"symbol":"ETH","symbolLogo":"https://cdn.coinglasscdn.com/static/img/coins/ethereum-ETH.png","price":3200.1,"priceChangePercent24h":-0.8,"marketCap":385000000000,"openInterest":15000000000,"volUsd":21000000000,"avgFundingRateByOi":0.0098,"avgFundingRateByOiAPR":10.731}]}`,
+		"/api/openInterest/v3/chart": `{"dateList":[1700000000000,1700003600000],"priceList":[36500.1,36620.4],` +
+			`"dataMap":{"Binance":[161745430,181309262],"OKX":[95120331,96011200]}}`,
+		"/api/futures/home/statistics": `{"openInterest":202194926751,"oiH24Chain":1.59,"volUsd":98000000000,"volH24Chain":-26.59,"longRate":48.11,"shortRate":51.89}`,
+		"/api/derivative/exchange/list": `[{"exchangeName":"Binance","logo":"https://cdn.coinglasscdn.com/static/exchanges/270.png","openInterest":30000000000,"volUsd":60000000000,"liquidationVolUsd":95900704.33530666},` +
+			`{"exchangeName":"OKX","logo":"https://cdn.coinglasscdn.com/static/exchanges/okx.png","openInterest":12000000000,"volUsd":20000000000,"liquidationVolUsd":41200000.5}]`,
+		"/api/exchange/futures/pairInfo": `[{"symbol":"BTC","symbolLogo":"https://cdn.coinglasscdn.com/static/img/coins/bitcoin-BTC.png"},` +
+			`{"symbol":"ETH","symbolLogo":"https://cdn.coinglasscdn.com/static/img/coins/ethereum-ETH.png"}]`,
+		"/api/spot/support/coin": `["BTC","ETH","XRP","USDT","BNB","SOL"]`,
+	}
+}
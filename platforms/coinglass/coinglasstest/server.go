@@ -0,0 +1,185 @@
+// Package coinglasstest 提供离线测试用的Coinglass模拟服务
+//
+// 🧪 Server基于httptest实现与capi.coinglass.com相同的加密协议：
+// 读取请求头 cache-ts-v2，为每个请求生成随机动态密钥，通过 user 响应头
+// 下发加密后的动态密钥，并在响应体的 data 字段中返回加密、gzip压缩后的
+// 固定数据（fixture），从而可以在CI或离线环境中测试完整的请求解密流程。
+//
+// 使用示例:
+//
+//	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+//	defer server.Close()
+//
+//	spider := coinglass.NewSpider().SetBaseURL(server.URL)
+//	markets, err := spider.CoinMarkets(coinglass.CoinMarketsParams{})
+package coinglasstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/xieburoucoco/spider-hub/platforms/coinglass/codec"
+)
+
+// response 与线上接口一致的响应结构
+type response struct {
+	Code    string `json:"code"`
+	Msg     string `json:"msg"`
+	Data    string `json:"data"`
+	Success bool   `json:"success"`
+}
+
+// Server Coinglass模拟服务
+type Server struct {
+	*httptest.Server
+
+	mu       sync.RWMutex
+	fixtures map[string][]byte  // 📦 接口路径 -> 明文JSON
+	statuses map[string]int     // 🚦 接口路径 -> 强制返回的HTTP状态码
+	retries  map[string]string  // ⏸️ 接口路径 -> 强制状态码响应携带的 Retry-After
+	failures map[string]failure // 💥 接口路径 -> 接下来若干次请求返回的状态码
+	hits     map[string]int     // 📊 接口路径 -> 请求次数
+}
+
+// NewServer 启动模拟服务
+// 📋 fixtures的键为接口路径（如 /api/spot/support/coin），值会按SetFixture的规则编码
+func NewServer(fixtures map[string]interface{}) *Server {
+	s := &Server{
+		fixtures: make(map[string][]byte),
+		statuses: make(map[string]int),
+		retries:  make(map[string]string),
+		failures: make(map[string]failure),
+		hits:     make(map[string]int),
+	}
+	for path, payload := range fixtures {
+		s.SetFixture(path, payload)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// SetFixture 设置接口返回的明文数据
+// 🔧 payload为[]byte或string时按原始JSON处理，其他类型使用json.Marshal编码
+func (s *Server) SetFixture(path string, payload interface{}) {
+	var data []byte
+	switch v := payload.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			panic(fmt.Sprintf("coinglasstest: 编码fixture %s 失败: %v", path, err))
+		}
+		data = encoded
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures[path] = data
+}
+
+// SetStatus 强制接口返回指定的HTTP状态码，用于模拟限流、服务异常等情况
+// 🚦 status为0时恢复正常响应
+func (s *Server) SetStatus(path string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if status == 0 {
+		delete(s.statuses, path)
+		return
+	}
+	s.statuses[path] = status
+}
+
+// SetRetryAfter 设置强制状态码响应携带的 Retry-After 响应头（秒），用于模拟限流
+// ⏸️ seconds为0时不再携带
+func (s *Server) SetRetryAfter(path string, seconds int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if seconds == 0 {
+		delete(s.retries, path)
+		return
+	}
+	s.retries[path] = strconv.Itoa(seconds)
+}
+
+// failure 接下来 times 次请求返回 status
+type failure struct {
+	status int
+	times  int
+}
+
+// FailNext 接口接下来的 times 次请求返回指定状态码，之后恢复正常，用于测试重试
+// 💥 优先于 SetStatus 设置的状态码
+func (s *Server) FailNext(path string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = failure{status: status, times: times}
+}
+
+// Hits 返回接口被请求的次数
+func (s *Server) Hits(path string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hits[path]
+}
+
+// handle 处理请求：校验时间戳、加密fixture并返回
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.hits[r.URL.Path]++
+	payload, ok := s.fixtures[r.URL.Path]
+	status := s.statuses[r.URL.Path]
+	retryAfter := s.retries[r.URL.Path]
+	if f := s.failures[r.URL.Path]; f.times > 0 {
+		status = f.status
+		f.times--
+		s.failures[r.URL.Path] = f
+	}
+	s.mu.Unlock()
+
+	if status != 0 {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+		return
+	}
+
+	cacheTsV2 := r.Header.Get("cache-ts-v2")
+	if cacheTsV2 == "" {
+		http.Error(w, "missing cache-ts-v2", http.StatusBadRequest)
+		return
+	}
+
+	if !ok {
+		writeJSON(w, response{Code: "404", Msg: "not found", Success: false})
+		return
+	}
+
+	dynamicKey, err := codec.NewDynamicKey()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	userHeader, data, err := codec.Encrypt(cacheTsV2, dynamicKey, payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("user", userHeader)
+	writeJSON(w, response{Code: "0", Msg: "success", Data: data, Success: true})
+}
+
+// writeJSON 输出JSON响应
+func writeJSON(w http.ResponseWriter, body response) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,29 @@
+package coinglass
+
+// API基础地址
+const (
+	DefaultBaseURL = "https://capi.coinglass.com" // 🌐 Coinglass API默认地址
+)
+
+// API接口路径
+const (
+	CoinMarketsPath            = "/api/home/v2/coinMarkets"       // 💰 币种市场数据
+	OpenInterestChartPath      = "/api/openInterest/v3/chart"     // 📈 持仓量图表数据
+	FuturesHomeStatisticsPath  = "/api/futures/home/statistics"   // 🏠 期货首页统计
+	DerivativeExchangeListPath = "/api/derivative/exchange/list"  // 🏢 衍生品交易所列表
+	FuturesPairInfoPath        = "/api/exchange/futures/pairInfo" // 🔗 期货交易对信息
+	SpotSupportCoinsPath       = "/api/spot/support/coin"         // 🪙 现货支持币种
+)
+
+// 币种市场数据默认参数
+const (
+	DefaultPageNum  = 1     // 📄 默认页码
+	DefaultPageSize = 20    // 📊 默认每页数量
+	DefaultExchange = "all" // 🏢 默认交易所筛选
+)
+
+// 持仓量图表默认参数
+const (
+	DefaultSymbol   = "BTC" // 🪙 默认币种
+	DefaultCurrency = "USD" // 💵 默认计价货币
+)
@@ -1,8 +1,24 @@
 package coinglass
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/xieburoucoco/spider-hub/errs"
+	"github.com/xieburoucoco/spider-hub/platforms"
+	"github.com/xieburoucoco/spider-hub/platforms/coinglass/coinglasstest"
+	"github.com/xieburoucoco/spider-hub/proxy"
+	"github.com/xieburoucoco/spider-hub/ratelimit"
+	"github.com/xieburoucoco/spider-hub/retry"
 )
 
 // 创建爬虫实例
@@ -145,6 +161,377 @@ func TestSpotSupportCoin(t *testing.T) {
 	t.Logf("✅ 现货支持币种获取成功，数据长度: %d, 数据预览: %s", len(result), preview)
 }
 
+// 🧩 TestOfflineTypedDecoding 测试类型化接口方法的结构体解析
+// 📋 使用本地模拟服务返回字段齐全的数据，验证解密后的JSON逐字段解析为对应结构体（不依赖外网）
+func TestOfflineTypedDecoding(t *testing.T) {
+	server := coinglasstest.NewServer(nil)
+	defer server.Close()
+	offline := NewSpider().SetBaseURL(server.URL)
+
+	server.SetFixture(CoinMarketsPath, `{"total":1,"pageSize":5,"list":[{"symbol":"BTC","symbolLogo":"https://cdn.example.com/btc.png",`+
+		`"price":65000.5,"priceChangePercent24h":1.25,"marketCap":1.28e12,"openInterest":3.5e10,"openInterestChangePercent24h":-2.5,`+
+		`"volUsd":5.2e10,"avgFundingRateByOi":0.011645,"avgFundingRateByOiAPR":12.7513,"avgFundingRateByVol":0.0102}]}`)
+	markets, err := offline.CoinMarkets(CoinMarketsParams{PageSize: 5})
+	wantMarkets := &CoinMarketsResult{Total: 1, PageSize: 5, List: []CoinMarket{{
+		Symbol: "BTC", SymbolLogo: "https://cdn.example.com/btc.png", Price: 65000.5, PriceChangePercent24h: 1.25,
+		MarketCap: 1.28e12, OpenInterest: 3.5e10, OpenInterestChangePercent24h: -2.5, VolUsd: 5.2e10,
+		AvgFundingRateByOi: 0.011645, AvgFundingRateByOiAPR: 12.7513, AvgFundingRateByVol: 0.0102,
+	}}}
+	if err != nil || !reflect.DeepEqual(markets, wantMarkets) {
+		t.Fatalf("❌ CoinMarkets解析错误: %+v, %v", markets, err)
+	}
+
+	server.SetFixture(OpenInterestChartPath, `{"dateList":[1700000000000],"priceList":[36500.1],"dataMap":{"Binance":[161745430]}}`)
+	chart, err := offline.OpenInterestChart(OpenInterestChartParams{Symbol: "BTC"})
+	wantChart := &OpenInterestChart{DateList: []int64{1700000000000}, PriceList: []float64{36500.1}, DataMap: map[string][]float64{"Binance": {161745430}}}
+	if err != nil || !reflect.DeepEqual(chart, wantChart) {
+		t.Fatalf("❌ OpenInterestChart解析错误: %+v, %v", chart, err)
+	}
+
+	server.SetFixture(FuturesHomeStatisticsPath, `{"openInterest":2.02e11,"oiH24Chain":1.59,"volUsd":9.8e10,"volH24Chain":-26.59,"longRate":48.11,"shortRate":51.89}`)
+	stats, err := offline.FuturesHomeStatistics()
+	wantStats := FuturesHomeStatistics{OpenInterest: 2.02e11, OiH24Chain: 1.59, VolUsd: 9.8e10, VolH24Chain: -26.59, LongRate: 48.11, ShortRate: 51.89}
+	if err != nil || stats == nil || *stats != wantStats {
+		t.Fatalf("❌ FuturesHomeStatistics解析错误: %+v, %v", stats, err)
+	}
+
+	server.SetFixture(DerivativeExchangeListPath, `[{"exchangeName":"OKX","logo":"https://cdn.example.com/okx.png","openInterest":1.2e10,"volUsd":2e10,"liquidationVolUsd":41200000.5}]`)
+	exchanges, err := offline.DerivativeExchangeList()
+	wantExchanges := []DerivativeExchange{{ExchangeName: "OKX", Logo: "https://cdn.example.com/okx.png", OpenInterest: 1.2e10, VolUsd: 2e10, LiquidationVolUsd: 41200000.5}}
+	if err != nil || !reflect.DeepEqual(exchanges, wantExchanges) {
+		t.Fatalf("❌ DerivativeExchangeList解析错误: %+v, %v", exchanges, err)
+	}
+
+	server.SetFixture(FuturesPairInfoPath, `[{"symbol":"ETH","symbolLogo":"https://cdn.example.com/eth.png"}]`)
+	pairs, err := offline.FuturesPairInfo()
+	if err != nil || !reflect.DeepEqual(pairs, []FuturesPair{{Symbol: "ETH", SymbolLogo: "https://cdn.example.com/eth.png"}}) {
+		t.Fatalf("❌ FuturesPairInfo解析错误: %+v, %v", pairs, err)
+	}
+
+	server.SetFixture(SpotSupportCoinsPath, `["BTC","ETH"]`)
+	coins, err := offline.SpotSupportCoins()
+	if err != nil || !reflect.DeepEqual(coins, []string{"BTC", "ETH"}) {
+		t.Fatalf("❌ SpotSupportCoins解析错误: %v, %v", coins, err)
+	}
+
+	// 📄 数据与结构体不匹配时返回 ErrParse
+	server.SetFixture(FuturesPairInfoPath, `{"symbol":"ETH"}`)
+	if _, err := offline.FuturesPairInfo(); !errors.Is(err, errs.ErrParse) {
+		t.Fatalf("❌ 期望解析错误，实际: %v", err)
+	}
+}
+
+// 🛑 TestGetDataWithContextCancel 测试上下文取消能中断进行中的请求
+// 🧪 使用本地挂起的HTTP服务，不依赖外网
+func TestGetDataWithContextCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewSpider().GetDataWithContext(ctx, server.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("❌ 期望context.DeadlineExceeded，实际: %v", err)
+	}
+	if errors.Is(err, ErrDecrypt) {
+		t.Fatalf("❌ 上下文错误不应被归类为解密错误: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("❌ 取消后请求未及时返回，耗时: %s", elapsed)
+	}
+	t.Logf("✅ 请求已按期限中断: %v", err)
+}
+
+// 🔓 TestDecryptDataErrors 测试解密阶段的错误分类
+func TestDecryptDataErrors(t *testing.T) {
+	response := &CoinglassResponse{Data: "not-base64!"}
+
+	// ❌ 无效数据应返回ErrDecrypt，并标明出错阶段
+	_, err := spider.decryptData(context.Background(), response, "1700000000000", "not-base64!")
+	var decryptErr *errs.DecryptError
+	if !errors.Is(err, ErrDecrypt) || !errors.As(err, &decryptErr) || decryptErr.Stage != errs.StageUserHeader {
+		t.Fatalf("❌ 期望user header阶段的ErrDecrypt，实际: %v", err)
+	}
+
+	// 🛑 已取消的上下文应直接返回context.Canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = spider.decryptData(ctx, response, "1700000000000", "not-base64!")
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrDecrypt) {
+		t.Fatalf("❌ 期望context.Canceled，实际: %v", err)
+	}
+}
+
+// 🧪 TestOfflineTypedEndpoints 使用本地模拟服务测试完整的请求解密流程
+// 📴 不依赖外网，适合CI和离线环境
+func TestOfflineTypedEndpoints(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	offline := NewSpider().SetBaseURL(server.URL)
+
+	markets, err := offline.CoinMarkets(CoinMarketsParams{PageSize: 5})
+	if err != nil {
+		t.Fatalf("❌ CoinMarkets失败: %v", err)
+	}
+	if len(markets.List) != 2 || markets.List[0].Symbol != "BTC" || markets.List[0].Price != 65000.5 {
+		t.Fatalf("❌ CoinMarkets结果不符: %+v", markets)
+	}
+
+	chart, err := offline.OpenInterestChart(OpenInterestChartParams{})
+	if err != nil {
+		t.Fatalf("❌ OpenInterestChart失败: %v", err)
+	}
+	if len(chart.DateList) != 2 || len(chart.DataMap["Binance"]) != 2 {
+		t.Fatalf("❌ OpenInterestChart结果不符: %+v", chart)
+	}
+
+	stats, err := offline.FuturesHomeStatistics()
+	if err != nil {
+		t.Fatalf("❌ FuturesHomeStatistics失败: %v", err)
+	}
+	if stats.LongRate != 48.11 || stats.ShortRate != 51.89 {
+		t.Fatalf("❌ FuturesHomeStatistics结果不符: %+v", stats)
+	}
+
+	exchanges, err := offline.DerivativeExchangeList()
+	if err != nil || len(exchanges) != 2 || exchanges[0].ExchangeName != "Binance" {
+		t.Fatalf("❌ DerivativeExchangeList结果不符: %+v, %v", exchanges, err)
+	}
+
+	pairs, err := offline.FuturesPairInfo()
+	if err != nil || len(pairs) != 2 || pairs[1].Symbol != "ETH" {
+		t.Fatalf("❌ FuturesPairInfo结果不符: %+v, %v", pairs, err)
+	}
+
+	coins, err := offline.SpotSupportCoins()
+	if err != nil || len(coins) != 6 {
+		t.Fatalf("❌ SpotSupportCoins结果不符: %v, %v", coins, err)
+	}
+
+	if hits := server.Hits(CoinMarketsPath); hits != 1 {
+		t.Fatalf("❌ CoinMarkets请求次数错误: %d", hits)
+	}
+	t.Logf("✅ 离线接口测试通过")
+}
+
+// 🚦 TestOfflineHTTPStatus 测试模拟服务返回异常状态码时的错误
+func TestOfflineHTTPStatus(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	server.SetStatus(SpotSupportCoinsPath, http.StatusServiceUnavailable)
+	_, err := NewSpider().SetBaseURL(server.URL).SpotSupportCoins()
+	if err == nil {
+		t.Fatal("❌ 503响应应返回错误")
+	}
+	if errors.Is(err, ErrDecrypt) {
+		t.Fatalf("❌ HTTP错误不应被归类为解密错误: %v", err)
+	}
+	var httpErr *errs.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable || !httpErr.Temporary() {
+		t.Fatalf("❌ 期望503的HTTPError，实际: %v", err)
+	}
+	t.Logf("✅ 返回错误: %v", err)
+}
+
+// 📮 TestOfflineAPIError 测试接口返回 success=false 时的错误分类
+func TestOfflineAPIError(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	// 没有fixture的接口返回 {"code":"404","success":false}，且不携带user响应头
+	_, err := NewSpider().SetBaseURL(server.URL).GetDataWithContext(context.Background(), server.URL+"/api/missing")
+	var apiErr *errs.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "404" || apiErr.Msg != "not found" {
+		t.Fatalf("❌ 期望错误码404的APIError，实际: %v", err)
+	}
+	if errors.Is(err, ErrDecrypt) {
+		t.Fatalf("❌ 接口错误不应被归类为解密错误: %v", err)
+	}
+	if !errors.Is(err, errs.ErrAPI) || !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("❌ 期望同时属于 ErrAPI 和 ErrNotFound，实际: %v", err)
+	}
+	t.Logf("✅ 返回错误: %v", err)
+}
+
+// 🔑 TestOfflineMissingUserHeader 测试成功响应缺少user响应头时的错误分类
+func TestOfflineMissingUserHeader(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":"0","success":true,"data":"bm90LWVuY3J5cHRlZA=="}`))
+	}))
+	defer server.Close()
+
+	_, err := NewSpider().GetDataWithContext(context.Background(), server.URL)
+	var decryptErr *errs.DecryptError
+	if !errors.As(err, &decryptErr) || decryptErr.Stage != errs.StageUserHeader || !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("❌ 期望user header阶段的DecryptError，实际: %v", err)
+	}
+	// 缺少响应头不是时钟偏差，重试也不会成功
+	if decryptErr.Temporary() || atomic.LoadInt32(&hits) != 1 {
+		t.Fatalf("❌ 缺少user响应头不应重试，请求次数: %d", atomic.LoadInt32(&hits))
+	}
+	t.Logf("✅ 返回错误: %v", err)
+}
+
+// 🧩 TestPlatformFetch 测试通过注册中心统一入口调用Coinglass
+func TestPlatformFetch(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	if _, ok := platforms.Lookup(PlatformName); !ok {
+		t.Fatal("❌ coinglass平台未注册")
+	}
+
+	platform := NewPlatform(NewSpider().SetBaseURL(server.URL))
+	result, err := platform.Fetch(context.Background(), platforms.Request{
+		Action: ActionGet,
+		Target: CoinMarketsPath,
+		Params: map[string]string{"pageSize": "5"},
+	})
+	if err != nil {
+		t.Fatalf("❌ Fetch失败: %v", err)
+	}
+
+	var markets CoinMarketsResult
+	if err := json.Unmarshal(result.Data.(json.RawMessage), &markets); err != nil || len(markets.List) == 0 {
+		t.Fatalf("❌ 结果解析失败: %v", err)
+	}
+	t.Logf("✅ 通过注册中心获取 %d 个币种", len(markets.List))
+}
+
+// 🔄 TestOfflineProxyPool 测试代理池轮换：被封禁的代理进入冷却，后续请求走健康代理
+func TestOfflineProxyPool(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	// 🚫 封禁代理：所有请求返回403
+	blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer blocked.Close()
+	// ✅ 健康代理：原样转发到目标地址
+	var forwarded int32
+	healthy := httptest.NewServer(&httputil.ReverseProxy{Director: func(r *http.Request) {
+		atomic.AddInt32(&forwarded, 1)
+	}})
+	defer healthy.Close()
+
+	pool := proxy.NewStaticPool(blocked.URL, healthy.URL)
+	s := NewSpider().SetBaseURL(server.URL).SetProxyPool(pool)
+
+	// 轮询第一次选中封禁代理
+	if _, err := s.SpotSupportCoins(); err == nil {
+		t.Fatal("❌ 封禁代理的请求应返回错误")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := s.SpotSupportCoins(); err != nil {
+			t.Fatalf("❌ 第%d次请求应走健康代理: %v", i+1, err)
+		}
+	}
+
+	stats := pool.Stats()
+	if stats[0].Blocks != 1 || stats[0].Available || stats[1].Successes != 3 {
+		t.Fatalf("❌ 代理健康状态错误: %+v", stats)
+	}
+	if atomic.LoadInt32(&forwarded) != 3 || server.Hits(SpotSupportCoinsPath) != 3 {
+		t.Fatalf("❌ 转发次数错误: 代理 %d, 服务端 %d", atomic.LoadInt32(&forwarded), server.Hits(SpotSupportCoinsPath))
+	}
+	t.Logf("✅ 代理状态: %+v", stats)
+}
+
+// ⏳ TestOfflineRateLimit 测试限速器的请求间隔和 Retry-After
+func TestOfflineRateLimit(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	s := NewSpider().SetBaseURL(server.URL).SetRateLimiter(ratelimit.New(20, 1))
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := s.SpotSupportCoins(); err != nil {
+			t.Fatalf("❌ 第%d次请求失败: %v", i+1, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("❌ 每秒20次时5个请求至少需要200ms，实际 %v", elapsed)
+	}
+
+	// 🚦 429 + Retry-After: 1，之后的请求至少等待1秒
+	server.SetStatus(SpotSupportCoinsPath, http.StatusTooManyRequests)
+	server.SetRetryAfter(SpotSupportCoinsPath, 1)
+	if _, err := s.SpotSupportCoins(); err == nil {
+		t.Fatal("❌ 429响应应返回错误")
+	}
+	server.SetStatus(SpotSupportCoinsPath, 0)
+
+	start = time.Now()
+	if _, err := s.SpotSupportCoins(); err != nil {
+		t.Fatalf("❌ 限流结束后请求失败: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Fatalf("❌ 应按 Retry-After 等待约1秒，实际 %v", elapsed)
+	}
+	t.Logf("✅ Retry-After 等待 %v", time.Since(start))
+}
+
+// 🔁 TestOfflineRetry 测试临时错误按重试策略重试，不可重试的错误立即返回
+func TestOfflineRetry(t *testing.T) {
+	server := coinglasstest.NewServer(coinglasstest.DefaultFixtures())
+	defer server.Close()
+
+	policy := retry.Default()
+	policy.InitialInterval = 10 * time.Millisecond
+	s := NewSpider().SetBaseURL(server.URL).SetRetryPolicy(policy)
+
+	server.FailNext(SpotSupportCoinsPath, http.StatusBadGateway, 2)
+	if coins, err := s.SpotSupportCoins(); err != nil || len(coins) == 0 {
+		t.Fatalf("❌ 两次502后应重试成功: %v", err)
+	}
+	if hits := server.Hits(SpotSupportCoinsPath); hits != 3 {
+		t.Fatalf("❌ 期望请求3次，实际 %d", hits)
+	}
+
+	// 🚫 404 不重试
+	server.FailNext(CoinMarketsPath, http.StatusNotFound, 3)
+	if _, err := s.CoinMarkets(CoinMarketsParams{PageSize: 5}); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("❌ 期望404错误，实际 %v", err)
+	}
+	if hits := server.Hits(CoinMarketsPath); hits != 1 {
+		t.Fatalf("❌ 404不应重试，实际请求 %d 次", hits)
+	}
+
+	// 📮 没有fixture的接口返回 success=false 且不带user响应头，不重试
+	if _, err := s.GetDataWithContext(context.Background(), server.URL+"/api/missing"); !errors.Is(err, errs.ErrAPI) {
+		t.Fatalf("❌ 期望接口错误，实际 %v", err)
+	}
+	if hits := server.Hits("/api/missing"); hits != 1 {
+		t.Fatalf("❌ success=false 不应重试，实际请求 %d 次", hits)
+	}
+
+	// 🔢 超过重试次数返回最后一次的错误
+	server.FailNext(FuturesPairInfoPath, http.StatusServiceUnavailable, 5)
+	var httpErr *errs.HTTPError
+	if _, err := s.SetRetryCount(1).FuturesPairInfo(); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("❌ 期望503错误，实际 %v", err)
+	}
+	if hits := server.Hits(FuturesPairInfoPath); hits != 2 {
+		t.Fatalf("❌ 重试1次应请求2次，实际 %d", hits)
+	}
+	t.Logf("✅ 重试测试通过")
+}
+
 // 🔧 buildURLWithParams 构建带参数的URL
 // 📝 将参数映射转换为URL查询字符串并拼接到基础URL上
 func buildURLWithParams(baseURL string, params map[string]string) string {
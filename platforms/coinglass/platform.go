@@ -0,0 +1,89 @@
+package coinglass
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/xieburoucoco/spider-hub/errs"
+	"github.com/xieburoucoco/spider-hub/platforms"
+)
+
+// PlatformName 平台注册名称
+const PlatformName = "coinglass"
+
+// ActionGet 获取任意接口的解密数据
+const ActionGet = "get"
+
+func init() {
+	platforms.Register(NewPlatform(NewSpider()))
+}
+
+// Platform Coinglass平台适配器
+// 🧩 将Spider包装为 platforms.Platform，供注册中心统一调用
+type Platform struct {
+	spider *Spider
+}
+
+// NewPlatform 使用指定的Spider创建平台适配器
+func NewPlatform(spider *Spider) *Platform {
+	return &Platform{spider: spider}
+}
+
+// Name 平台名称
+func (p *Platform) Name() string {
+	return PlatformName
+}
+
+// Capabilities 平台支持的操作
+func (p *Platform) Capabilities() []platforms.Capability {
+	return []platforms.Capability{
+		{Action: ActionGet, Description: "获取任意接口的解密JSON数据，Params作为查询参数", Target: "接口路径（如 /api/spot/support/coin）或完整URL"},
+	}
+}
+
+// Fetch 执行抓取请求
+// 📊 Result.Data 为解密后的 json.RawMessage
+func (p *Platform) Fetch(ctx context.Context, req platforms.Request) (platforms.Result, error) {
+	switch req.Action {
+	case ActionGet:
+		apiURL, err := p.buildURL(req.Target, req.Params)
+		if err != nil {
+			return platforms.Result{}, err
+		}
+
+		data, err := p.spider.GetDataWithContext(ctx, apiURL)
+		if err != nil {
+			return platforms.Result{}, err
+		}
+		if !json.Valid([]byte(data)) {
+			return platforms.Result{}, &errs.ParseError{Platform: PlatformName, What: "解密结果", Err: fmt.Errorf("不是有效的JSON: %s", apiURL)}
+		}
+		return platforms.Result{Platform: PlatformName, Action: req.Action, Data: json.RawMessage(data)}, nil
+	default:
+		return platforms.Result{}, fmt.Errorf("❌ %w: %s %s", platforms.ErrUnsupportedAction, PlatformName, req.Action)
+	}
+}
+
+// buildURL 将接口路径或完整URL与查询参数合并
+func (p *Platform) buildURL(target string, params map[string]string) (string, error) {
+	if target == "" {
+		return "", fmt.Errorf("❌ 缺少接口路径")
+	}
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		target = p.spider.baseURL + "/" + strings.TrimLeft(target, "/")
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("❌ 无效的接口地址: %w", err)
+	}
+	query := u.Query()
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
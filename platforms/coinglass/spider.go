@@ -1,19 +1,32 @@
 package coinglass
 
 import (
-	"bytes"
-	"compress/gzip"
-	"crypto/aes"
-	"encoding/base64"
-	"encoding/hex"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/xieburoucoco/spider-hub/errs"
+	"github.com/xieburoucoco/spider-hub/fingerprint"
+	"github.com/xieburoucoco/spider-hub/internal/util"
+	"github.com/xieburoucoco/spider-hub/platforms/coinglass/codec"
+	"github.com/xieburoucoco/spider-hub/proxy"
+	"github.com/xieburoucoco/spider-hub/ratelimit"
+	"github.com/xieburoucoco/spider-hub/retry"
 )
 
+// ErrDecrypt 解密失败
+// 🔓 解密流程任一阶段出错时返回 *errs.DecryptError，均可通过 errors.Is(err, ErrDecrypt) 判断，
+// 用于与网络错误、上下文取消等错误区分；出错阶段见 DecryptError.Stage
+var ErrDecrypt = errs.ErrDecrypt
+
+// errMissingUserHeader 响应中没有携带动态密钥的user响应头
+var errMissingUserHeader = errors.New("未找到user header")
+
 // CoinglassResponse API响应结构
 // 🔐 所有Coinglass API都返回加密的响应数据
 // 📦 需要通过特定的解密流程才能获取原始JSON数据
@@ -26,9 +39,19 @@ type CoinglassResponse struct {
 
 // Spider Coinglass爬虫
 // 🕷️ 专门用于抓取Coinglass平台加密货币数据的爬虫实例
-// 🔧 内置HTTP客户端和加密解密功能
+// 🔧 内置HTTP客户端和加密解密功能，可在多个goroutine中并发使用
 type Spider struct {
-	client *resty.Client // 🌐 HTTP请求客户端
+	client  *resty.Client // 🌐 HTTP请求客户端
+	baseURL string        // 🏠 类型化接口使用的API地址
+
+	timeout     time.Duration // ⏱️ 单次请求超时
+	retryPolicy retry.Policy  // 🔁 重试策略
+
+	proxyURL     string                // 🌐 共享客户端使用的代理地址
+	proxyClients *util.ClientCache     // 🗂️ 按代理地址缓存的客户端
+	proxyPool    proxy.Pool            // 🔄 代理池（可选）
+	limiter      *ratelimit.Limiter    // ⏳ 限速器（可选）
+	fingerprints *fingerprint.Selector // 🎭 浏览器指纹选择器（可选）
 }
 
 // NewSpider 创建新的Coinglass爬虫实例
@@ -40,12 +63,124 @@ type Spider struct {
 //	spider := NewSpider()
 //	data, err := spider.GetData("https://capi.coinglass.com/api/...")
 func NewSpider() *Spider {
-	client := resty.New()
-	client.SetTimeout(30 * time.Second) // ⏱️ 设置30秒超时
+	s := &Spider{
+		baseURL:     DefaultBaseURL,
+		timeout:     30 * time.Second, // ⏱️ 设置30秒超时
+		retryPolicy: retry.Default(),  // 🔁 最多尝试3次
+	}
+	s.client = s.newClient("")
+	s.proxyClients = util.NewClientCache(s.newClient)
+	return s
+}
 
-	return &Spider{
-		client: client,
+// newClient 按当前配置创建使用指定代理的HTTP客户端，proxyURL为空时不设置代理
+func (s *Spider) newClient(proxyURL string) *resty.Client {
+	// 🔁 重试由 retryPolicy 在整个请求+解密流程之上处理，客户端本身不重试
+	client := resty.New().SetTimeout(s.timeout)
+	if proxyURL != "" {
+		client.SetProxy(proxyURL)
 	}
+
+	// ⏳ 限速按实际使用的代理分桶，共享客户端使用 SetProxy 设置的代理
+	return util.UseRateLimiter(client, func() *ratelimit.Limiter { return s.limiter }, func() string {
+		if proxyURL != "" {
+			return proxyURL
+		}
+		return s.proxyURL
+	})
+}
+
+// SetBaseURL 设置类型化接口使用的API地址
+// 🧪 可指向 coinglasstest 模拟服务或自建代理，默认为 DefaultBaseURL
+// 📝 返回Spider本身以便链式调用
+func (s *Spider) SetBaseURL(baseURL string) *Spider {
+	s.baseURL = strings.TrimRight(baseURL, "/")
+	return s
+}
+
+// SetTimeout 设置单次HTTP请求的超时时间（默认30秒）
+func (s *Spider) SetTimeout(timeout time.Duration) *Spider {
+	s.timeout = timeout
+	s.client.SetTimeout(timeout)
+	s.proxyClients.Reset()
+	return s
+}
+
+// SetProxy 设置所有请求使用的代理地址，如 http://127.0.0.1:7890
+// 🔄 设置了代理池时以代理池为准
+func (s *Spider) SetProxy(proxyURL string) *Spider {
+	s.proxyURL = proxyURL
+	s.client.SetProxy(proxyURL)
+	return s
+}
+
+// SetProxyPool 设置代理池
+// 🔄 每次请求从代理池中选取代理，并根据响应状态码上报代理健康状态
+func (s *Spider) SetProxyPool(pool proxy.Pool) *Spider {
+	s.proxyPool = pool
+	return s
+}
+
+// SetRateLimiter 设置限速器
+// ⏳ 每次请求前按限速器的范围（站点/代理）等待，收到 429/503 时按 Retry-After 暂停；
+// 同一个限速器可以在多个爬虫之间共享
+func (s *Spider) SetRateLimiter(limiter *ratelimit.Limiter) *Spider {
+	s.limiter = limiter
+	return s
+}
+
+// SetFingerprints 设置浏览器指纹选择器（默认固定使用 fingerprint.DefaultProfile）
+// 🎭 每次请求的 user-agent、sec-ch-ua 系列请求头由选择的指纹生成，同一 proxy.WithSession 会话使用同一个指纹
+func (s *Spider) SetFingerprints(selector *fingerprint.Selector) *Spider {
+	s.fingerprints = selector
+	return s
+}
+
+// profile 返回本次请求使用的浏览器指纹
+func (s *Spider) profile(ctx context.Context) fingerprint.Profile {
+	if s.fingerprints == nil {
+		return fingerprint.DefaultProfile
+	}
+	return s.fingerprints.Pick(proxy.SessionFromContext(ctx))
+}
+
+// SetRetryCount 设置请求失败时的重试次数（默认2次），0 表示不重试
+// 🔁 只修改重试策略的次数，退避间隔等其他设置不变
+func (s *Spider) SetRetryCount(count int) *Spider {
+	s.retryPolicy.MaxAttempts = count + 1
+	return s
+}
+
+// SetRetryPolicy 设置重试策略
+// 🔁 超时、网络中断、429/5xx 以及 user 响应头密钥不匹配（本地时钟偏差）按指数退避重试，
+// 每次重试使用新的时间戳
+func (s *Spider) SetRetryPolicy(policy retry.Policy) *Spider {
+	s.retryPolicy = policy
+	return s
+}
+
+// execute 发送单次请求
+// 🔄 配置了代理池时选取代理发送，并根据结果上报代理健康状态（调用方取消的请求不计入）
+func (s *Spider) execute(ctx context.Context, send func(req *resty.Request) (*resty.Response, error)) (*resty.Response, error) {
+	pool := s.proxyPool
+	if pool == nil {
+		return send(s.client.R().SetContext(ctx))
+	}
+
+	proxyURL, err := pool.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取代理失败: %w", err)
+	}
+	resp, err := send(s.proxyClients.Get(proxyURL).R().SetContext(ctx))
+
+	if ctx.Err() == nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode()
+		}
+		pool.Report(proxyURL, proxy.OutcomeFor(statusCode, err))
+	}
+	return resp, err
 }
 
 // GetData 获取任意API数据（通用方法）
@@ -71,285 +206,262 @@ func NewSpider() *Spider {
 //	🔗 /api/exchange/futures/pairInfo - 期货交易对信息
 //	🪙 /api/spot/support/coin - 现货支持币种
 func (s *Spider) GetData(apiURL string) (string, error) {
-	// ⏰ 生成时间戳作为加密密钥的一部分
+	return s.GetDataWithContext(context.Background(), apiURL)
+}
+
+// GetDataWithContext 获取任意API数据（支持上下文控制）
+// 🛑 ctx取消或超时会中断进行中的HTTP请求以及后续的解密流程
+// 🔍 上下文错误会原样包装返回，可通过 errors.Is(err, context.Canceled)
+// 或 errors.Is(err, context.DeadlineExceeded) 判断；解密失败可通过
+// errors.Is(err, ErrDecrypt) 判断
+// 🔁 可重试的错误按重试策略重试，返回最后一次的错误
+func (s *Spider) GetDataWithContext(ctx context.Context, apiURL string) (string, error) {
+	var data string
+	err := s.retryPolicy.Do(ctx, func() (err error) {
+		data, err = s.getData(ctx, apiURL)
+		return err
+	})
+	return data, err
+}
+
+// getData 单次获取并解密API数据
+func (s *Spider) getData(ctx context.Context, apiURL string) (string, error) {
+	// ⏰ 生成时间戳作为加密密钥的一部分（每次尝试重新生成）
 	cacheTsV2 := fmt.Sprintf("%d", time.Now().UnixMilli())
 
 	// 🔐 第一步：获取加密的响应数据和动态密钥
-	response, userHeader, err := s.getEncryptedData(apiURL, cacheTsV2)
+	response, userHeader, err := s.getEncryptedData(ctx, apiURL, cacheTsV2)
 	if err != nil {
-		return "", fmt.Errorf("获取加密数据失败: %v", err)
+		return "", fmt.Errorf("获取加密数据失败: %w", err)
 	}
 
 	// 🔓 第二步：解密数据并解压gzip
-	decryptedData, err := s.decryptData(response, cacheTsV2, userHeader)
+	decryptedData, err := s.decryptData(ctx, response, cacheTsV2, userHeader)
 	if err != nil {
-		return "", fmt.Errorf("解密数据失败: %v", err)
+		return "", fmt.Errorf("解密数据失败: %w", err)
 	}
 
 	return decryptedData, nil
 }
 
-// getEncryptedData 获取加密响应数据
-// 🌐 向Coinglass API发送请求，获取加密的响应数据
-// 🔑 同时获取用于解密的动态密钥（通过response header传递）
-// 🎭 模拟真实浏览器的请求头，避免被反爬虫检测
-//
-// 参数:
-//
-//	apiURL    - 🌐 API请求地址
-//	cacheTsV2 - ⏰ 时间戳，用于生成解密密钥
+// CoinMarkets 获取币种市场数据
+// 💰 对应 /api/home/v2/coinMarkets，返回分页的币种市场排行
 //
-// 返回:
+// 使用示例:
 //
-//	*CoinglassResponse - 📦 加密的API响应结构
-//	string            - 🔑 用户动态密钥（从response header获取）
-//	error             - ❌ 请求错误信息
-func (s *Spider) getEncryptedData(apiURL, cacheTsV2 string) (*CoinglassResponse, string, error) {
-	// 🎭 设置完整的浏览器请求头，模拟真实用户访问
-	headers := map[string]string{
-		"accept":             "application/json",                                                                                                // 📋 接受JSON响应
-		"accept-language":    "zh-CN,zh;q=0.9",                                                                                                  // 🌏 语言偏好
-		"cache-ts-v2":        cacheTsV2,                                                                                                         // ⏰ 时间戳密钥
-		"encryption":         "true",                                                                                                            // 🔐 启用加密
-		"language":           "zh",                                                                                                              // 🈳 界面语言
-		"origin":             "https://www.coinglass.com",                                                                                       // 🏠 请求来源
-		"priority":           "u=1, i",                                                                                                          // 🚦 请求优先级
-		"referer":            "https://www.coinglass.com/",                                                                                      // 🔗 引用页面
-		"sec-ch-ua":          `"Not)A;Brand";v="8", "Chromium";v="138", "Google Chrome";v="138"`,                                                // 🌐 浏览器标识
-		"sec-ch-ua-mobile":   "?0",                                                                                                              // 📱 非移动设备
-		"sec-ch-ua-platform": `"Windows"`,                                                                                                       // 💻 操作系统
-		"sec-fetch-dest":     "empty",                                                                                                           // 🎯 请求目标
-		"sec-fetch-mode":     "cors",                                                                                                            // 🔄 CORS模式
-		"sec-fetch-site":     "same-site",                                                                                                       // 🏠 同站请求
-		"user-agent":         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/138.0.0.0 Safari/537.36", // 🕷️ 用户代理
-	}
+//	result, err := spider.CoinMarkets(CoinMarketsParams{PageSize: 5})
+func (s *Spider) CoinMarkets(params CoinMarketsParams) (*CoinMarketsResult, error) {
+	return s.CoinMarketsWithContext(context.Background(), params)
+}
 
-	// 🚀 发送HTTP GET请求
-	resp, err := s.client.R().SetHeaders(headers).Get(apiURL)
-	if err != nil {
-		return nil, "", fmt.Errorf("请求失败: %v", err)
+// CoinMarketsWithContext 同CoinMarkets，支持上下文控制
+func (s *Spider) CoinMarketsWithContext(ctx context.Context, params CoinMarketsParams) (*CoinMarketsResult, error) {
+	var result CoinMarketsResult
+	if err := s.getJSON(ctx, CoinMarketsPath, params.values(), &result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
 
-	// 🔍 检查HTTP状态码
-	if resp.StatusCode() != 200 {
-		return nil, "", fmt.Errorf("HTTP状态码错误: %d", resp.StatusCode())
-	}
+// OpenInterestChart 获取持仓量图表数据
+// 📈 对应 /api/openInterest/v3/chart，返回指定币种各交易所的持仓量序列
+func (s *Spider) OpenInterestChart(params OpenInterestChartParams) (*OpenInterestChart, error) {
+	return s.OpenInterestChartWithContext(context.Background(), params)
+}
 
-	// 🔑 从响应头获取动态解密密钥
-	userHeader := resp.Header().Get("user")
-	if userHeader == "" {
-		return nil, "", fmt.Errorf("未找到user header")
+// OpenInterestChartWithContext 同OpenInterestChart，支持上下文控制
+func (s *Spider) OpenInterestChartWithContext(ctx context.Context, params OpenInterestChartParams) (*OpenInterestChart, error) {
+	var result OpenInterestChart
+	if err := s.getJSON(ctx, OpenInterestChartPath, params.values(), &result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
 
-	// 📦 解析JSON响应体
-	var response CoinglassResponse
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
-		return nil, "", fmt.Errorf("解析响应失败: %v", err)
+// FuturesHomeStatistics 获取期货首页统计数据
+// 🏠 对应 /api/futures/home/statistics，无需参数
+func (s *Spider) FuturesHomeStatistics() (*FuturesHomeStatistics, error) {
+	return s.FuturesHomeStatisticsWithContext(context.Background())
+}
+
+// FuturesHomeStatisticsWithContext 同FuturesHomeStatistics，支持上下文控制
+func (s *Spider) FuturesHomeStatisticsWithContext(ctx context.Context) (*FuturesHomeStatistics, error) {
+	var result FuturesHomeStatistics
+	if err := s.getJSON(ctx, FuturesHomeStatisticsPath, nil, &result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
 
-	return &response, userHeader, nil
+// DerivativeExchangeList 获取衍生品交易所列表
+// 🏢 对应 /api/derivative/exchange/list，无需参数
+func (s *Spider) DerivativeExchangeList() ([]DerivativeExchange, error) {
+	return s.DerivativeExchangeListWithContext(context.Background())
 }
 
-// decryptData 解密数据
-// 🔓 执行完整的数据解密流程，包括多层加密解密和数据解压
-// 🔐 使用时间戳密钥解密用户动态密钥
-// 🗜️ 使用动态密钥解密响应数据并解压gzip
-//
-// 解密流程:
-//  1. 📅 使用时间戳生成第一层密钥
-//  2. 🔑 解密user header获取动态密钥
-//  3. 📦 使用动态密钥解密响应数据
-//  4. 🗜️ 解压gzip获取最终JSON数据
-//
-// 参数:
-//
-//	response   - 📦 加密的API响应
-//	cacheTsV2  - ⏰ 时间戳密钥
-//	userHeader - 🔑 加密的动态密钥
-//
-// 返回:
-//
-//	string - 📄 解密后的JSON字符串
-//	error  - ❌ 解密过程中的错误
-func (s *Spider) decryptData(response *CoinglassResponse, cacheTsV2, userHeader string) (string, error) {
-	// 🔑 第一步：生成时间戳密钥（Base64编码后取前16位）
-	timestampEncoded := base64.StdEncoding.EncodeToString([]byte(cacheTsV2))
-	timestampKey := []byte(timestampEncoded[:16])
-
-	// 🔓 第二步：解密user header获取动态密钥
-	userCiphertext, err := base64.StdEncoding.DecodeString(userHeader)
-	if err != nil {
-		return "", fmt.Errorf("解码user header失败: %v", err)
+// DerivativeExchangeListWithContext 同DerivativeExchangeList，支持上下文控制
+func (s *Spider) DerivativeExchangeListWithContext(ctx context.Context) ([]DerivativeExchange, error) {
+	var result []DerivativeExchange
+	if err := s.getJSON(ctx, DerivativeExchangeListPath, nil, &result); err != nil {
+		return nil, err
 	}
+	return result, nil
+}
 
-	// 🔐 使用时间戳密钥解密用户动态密钥
-	dynamicKeyBytes, err := s.decryptAES(userCiphertext, timestampKey)
-	if err != nil {
-		return "", fmt.Errorf("解密user header失败: %v", err)
-	}
+// FuturesPairInfo 获取交易所期货交易对信息
+// 🔗 对应 /api/exchange/futures/pairInfo，无需参数
+func (s *Spider) FuturesPairInfo() ([]FuturesPair, error) {
+	return s.FuturesPairInfoWithContext(context.Background())
+}
 
-	// 🔄 转换动态密钥格式并解析
-	dynamicZipKey := hex.EncodeToString(dynamicKeyBytes)
-	dynamicKey, err := s.parseDecryptedHex(dynamicZipKey)
-	if err != nil {
-		return "", fmt.Errorf("解析动态密钥失败: %v", err)
+// FuturesPairInfoWithContext 同FuturesPairInfo，支持上下文控制
+func (s *Spider) FuturesPairInfoWithContext(ctx context.Context) ([]FuturesPair, error) {
+	var result []FuturesPair
+	if err := s.getJSON(ctx, FuturesPairInfoPath, nil, &result); err != nil {
+		return nil, err
 	}
+	return result, nil
+}
 
-	// 🔓 第三步：解密响应数据
-	ciphertext, err := base64.StdEncoding.DecodeString(response.Data)
-	if err != nil {
-		return "", fmt.Errorf("解码响应数据失败: %v", err)
+// SpotSupportCoins 获取现货支持币种列表
+// 🪙 对应 /api/spot/support/coin，返回币种符号列表
+func (s *Spider) SpotSupportCoins() ([]string, error) {
+	return s.SpotSupportCoinsWithContext(context.Background())
+}
+
+// SpotSupportCoinsWithContext 同SpotSupportCoins，支持上下文控制
+func (s *Spider) SpotSupportCoinsWithContext(ctx context.Context) ([]string, error) {
+	var result []string
+	if err := s.getJSON(ctx, SpotSupportCoinsPath, nil, &result); err != nil {
+		return nil, err
 	}
+	return result, nil
+}
 
-	// 🔐 使用动态密钥解密响应数据
-	dynamicKeyBytes = []byte(dynamicKey)
-	decryptedData, err := s.decryptAES(ciphertext, dynamicKeyBytes)
-	if err != nil {
-		return "", fmt.Errorf("解密响应数据失败: %v", err)
+// getJSON 请求指定接口并将解密后的JSON解析到out
+// 🔧 所有类型化接口的公共实现：拼接URL -> GetDataWithContext -> json.Unmarshal
+func (s *Spider) getJSON(ctx context.Context, path string, query url.Values, out interface{}) error {
+	apiURL := s.baseURL + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
 	}
 
-	// 🗜️ 第四步：解压gzip获取最终数据
-	hexString := hex.EncodeToString(decryptedData)
-	result, err := s.parseDecryptedHex(hexString)
+	data, err := s.GetDataWithContext(ctx, apiURL)
 	if err != nil {
-		return "", fmt.Errorf("解压最终数据失败: %v", err)
+		return err
 	}
 
-	return result, nil
+	if err := json.Unmarshal([]byte(data), out); err != nil {
+		return &errs.ParseError{Platform: PlatformName, What: path + "数据", Err: err}
+	}
+	return nil
 }
 
-// decryptAES 执行AES-ECB解密
-// 🔐 使用AES算法的ECB模式进行数据解密
-// 🔑 支持128位密钥长度（16字节）
-// 📦 自动处理PKCS7填充的去除
-//
-// AES-ECB特点:
-//
-//	🔒 每个数据块独立加密，相同明文产生相同密文
-//	⚡ 加密速度快，适合大量数据处理
-//	🚫 安全性相对较低，但足够用于API数据传输
+// getEncryptedData 获取加密响应数据
+// 🌐 向Coinglass API发送请求，获取加密的响应数据
+// 🔑 同时获取用于解密的动态密钥（通过response header传递）
+// 🎭 请求头由浏览器指纹生成，模拟真实浏览器访问，避免被反爬虫检测
 //
 // 参数:
 //
-//	ciphertext - 🔒 待解密的密文数据
-//	key        - 🔑 16字节的AES密钥
+//	ctx       - 🛑 上下文，取消时中断HTTP请求
+//	apiURL    - 🌐 API请求地址
+//	cacheTsV2 - ⏰ 时间戳，用于生成解密密钥
 //
 // 返回:
 //
-//	[]byte - 📄 解密后的原始数据
-//	error  - ❌ 解密过程中的错误
-func (s *Spider) decryptAES(ciphertext, key []byte) ([]byte, error) {
-	// 🔍 验证密文长度必须是AES块大小的倍数
-	if len(ciphertext)%aes.BlockSize != 0 {
-		return nil, fmt.Errorf("密文长度不是块大小的倍数")
-	}
-
-	// 🔑 验证密钥长度必须为16字节（AES-128）
-	if len(key) != 16 {
-		return nil, fmt.Errorf("密钥长度必须为16字节")
-	}
+//	*CoinglassResponse - 📦 加密的API响应结构
+//	string            - 🔑 用户动态密钥（从response header获取）
+//	error             - ❌ 请求错误信息
+func (s *Spider) getEncryptedData(ctx context.Context, apiURL, cacheTsV2 string) (*CoinglassResponse, string, error) {
+	// 🎭 设置与浏览器无关的请求头，user-agent、sec-ch-ua 等由浏览器指纹生成
+	headers := s.profile(ctx).Apply(map[string]string{
+		"accept":          "application/json",           // 📋 接受JSON响应
+		"accept-language": "zh-CN,zh;q=0.9",             // 🌏 语言偏好
+		"cache-ts-v2":     cacheTsV2,                    // ⏰ 时间戳密钥
+		"encryption":      "true",                       // 🔐 启用加密
+		"language":        "zh",                         // 🈳 界面语言
+		"origin":          "https://www.coinglass.com",  // 🏠 请求来源
+		"priority":        "u=1, i",                     // 🚦 请求优先级
+		"referer":         "https://www.coinglass.com/", // 🔗 引用页面
+		"sec-fetch-dest":  "empty",                      // 🎯 请求目标
+		"sec-fetch-mode":  "cors",                       // 🔄 CORS模式
+		"sec-fetch-site":  "same-site",                  // 🏠 同站请求
+	})
 
-	// 🔐 初始化AES解密器
-	block, err := aes.NewCipher(key)
+	// 🚀 发送HTTP GET请求
+	resp, err := s.execute(ctx, func(req *resty.Request) (*resty.Response, error) {
+		return req.SetHeaders(headers).Get(apiURL)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("初始化AES失败: %v", err)
+		return nil, "", fmt.Errorf("请求失败: %w", err)
 	}
 
-	// 🔄 逐块解密数据（ECB模式）
-	decrypted := make([]byte, len(ciphertext))
-	for i := 0; i < len(ciphertext); i += aes.BlockSize {
-		block.Decrypt(decrypted[i:i+aes.BlockSize], ciphertext[i:i+aes.BlockSize])
+	// 🔍 检查HTTP状态码
+	if resp.StatusCode() != 200 {
+		return nil, "", errs.NewHTTPError(PlatformName, apiURL, resp.StatusCode(), resp.Body())
 	}
 
-	// 📦 去除PKCS7填充
-	return s.pkcs7Unpad(decrypted)
-}
-
-// pkcs7Unpad 去除PKCS7填充
-// �� PKCS7是一种标准的数据填充方式，用于确保数据长度符合加密算法要求
-// 🔍 填充值等于填充字节的数量，例如填充3个字节则每个字节的值都是3
-// ✂️ 解密后需要去除这些填充字节以获取原始数据
-//
-// PKCS7填充规则:
-//
-//	📏 如果数据长度刚好是块大小的倍数，仍需添加一个完整的填充块
-//	🔢 填充字节的值等于填充的字节数
-//	✅ 例如：...ABC + [5,5,5,5,5] 表示填充了5个字节
-//
-// 参数:
-//
-//	data - 📄 包含PKCS7填充的解密数据
-//
-// 返回:
-//
-//	[]byte - 📄 去除填充后的原始数据
-//	error  - ❌ 填充格式错误
-func (s *Spider) pkcs7Unpad(data []byte) ([]byte, error) {
-	length := len(data)
-	if length == 0 {
-		return nil, fmt.Errorf("无效的解密数据长度")
+	// 📦 解析JSON响应体
+	var response CoinglassResponse
+	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+		return nil, "", &errs.ParseError{Platform: PlatformName, What: "响应", Err: err}
 	}
 
-	// 🔢 获取填充长度（最后一个字节的值）
-	paddingLen := int(data[length-1])
-
-	// 🔍 验证填充长度的合理性
-	if paddingLen > length || paddingLen > aes.BlockSize {
-		return nil, fmt.Errorf("无效的PKCS7填充")
+	// 📮 接口返回失败（如 {"code":"404","success":false}）时不携带user响应头，不是解密问题
+	if !response.Success {
+		return nil, "", &errs.APIError{Platform: PlatformName, URL: apiURL, Code: response.Code, Msg: response.Msg}
 	}
 
-	// ✅ 验证填充字节的正确性
-	for i := length - paddingLen; i < length; i++ {
-		if data[i] != byte(paddingLen) {
-			return nil, fmt.Errorf("无效的PKCS7填充字节")
-		}
+	// 🔑 从响应头获取动态解密密钥
+	userHeader := resp.Header().Get("user")
+	if userHeader == "" {
+		return nil, "", &errs.DecryptError{Platform: PlatformName, Stage: errs.StageUserHeader, Err: errMissingUserHeader}
 	}
 
-	// ✂️ 返回去除填充后的数据
-	return data[:length-paddingLen], nil
+	return &response, userHeader, nil
 }
 
-// parseDecryptedHex 解析十六进制字符串并解压gzip
-// 🔄 将十六进制字符串转换为字节数组
-// 🗜️ 使用gzip算法解压数据获取最终的JSON字符串
-// 📊 Coinglass使用gzip压缩来减少数据传输量
+// decryptData 解密数据
+// 🔓 执行完整的数据解密流程，包括多层加密解密和数据解压
+// 🔐 使用时间戳密钥解密用户动态密钥
+// 🗜️ 使用动态密钥解密响应数据并解压gzip
 //
-// 处理流程:
-//  1. 🔤 十六进制字符串 -> 字节数组
-//  2. 🗜️ gzip解压 -> 原始JSON字符串
-//  3. 📄 返回可解析的JSON数据
+// 具体算法见 codec 包，解密流程:
+//  1. 📅 使用时间戳生成第一层密钥
+//  2. 🔑 解密user header获取动态密钥
+//  3. 📦 使用动态密钥解密响应数据
+//  4. 🗜️ 解压gzip获取最终JSON数据
 //
 // 参数:
 //
-//	hexString - 🔤 十六进制编码的gzip压缩数据
+//	ctx        - 🛑 上下文，每个解密阶段开始前检查是否已取消
+//	response   - 📦 加密的API响应
+//	cacheTsV2  - ⏰ 时间戳密钥
+//	userHeader - 🔑 加密的动态密钥
 //
 // 返回:
 //
-//	string - 📄 解压后的JSON字符串
-//	error  - ❌ 解析或解压过程中的错误
-func (s *Spider) parseDecryptedHex(hexString string) (string, error) {
-	// 🔤 将十六进制字符串转换为字节数组
-	data, err := hex.DecodeString(hexString)
-	if err != nil {
-		return "", fmt.Errorf("解码十六进制失败: %v", err)
+//	string - 📄 解密后的JSON字符串
+//	error  - ❌ 解密过程中的错误（*errs.DecryptError）或上下文错误
+func (s *Spider) decryptData(ctx context.Context, response *CoinglassResponse, cacheTsV2, userHeader string) (string, error) {
+	// 🔑 第一、二步：使用时间戳密钥解密user header获取动态密钥
+	if err := ctx.Err(); err != nil {
+		return "", err
 	}
-
-	// 📖 创建字节读取器
-	reader := bytes.NewReader(data)
-
-	// 🗜️ 创建gzip解压器
-	gzipReader, err := gzip.NewReader(reader)
+	dynamicKey, err := codec.DecryptDynamicKey(cacheTsV2, userHeader)
 	if err != nil {
-		return "", fmt.Errorf("创建gzip reader失败: %v", err)
+		return "", err
 	}
-	defer gzipReader.Close() // 🔒 确保资源释放
 
-	// 📄 解压数据到缓冲区
-	var decompressed bytes.Buffer
-	if _, err := io.Copy(&decompressed, gzipReader); err != nil {
-		return "", fmt.Errorf("解压gzip失败: %v", err)
+	// 🔓 第三、四步：使用动态密钥解密响应数据并解压gzip
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	result, err := codec.DecryptPayload(dynamicKey, response.Data)
+	if err != nil {
+		return "", err
 	}
 
-	// 📊 返回解压后的JSON字符串
-	return decompressed.String(), nil
+	return string(result), nil
 }
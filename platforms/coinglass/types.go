@@ -0,0 +1,124 @@
+package coinglass
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// CoinMarketsParams 币种市场数据请求参数
+// 📋 对应 /api/home/v2/coinMarkets 的查询参数，零值字段使用默认值
+type CoinMarketsParams struct {
+	Sort     string // 🔄 排序字段（空表示默认排序）
+	Order    string // ⬆️ 排序方向（空表示默认方向）
+	Keyword  string // 🔍 搜索关键词（空表示不筛选）
+	PageNum  int    // 📄 页码（默认1）
+	PageSize int    // 📊 每页数量（默认20）
+	Exchange string // 🏢 交易所筛选（默认all）
+}
+
+// values 转换为URL查询参数
+func (p CoinMarketsParams) values() url.Values {
+	pageNum, pageSize, exchange := p.PageNum, p.PageSize, p.Exchange
+	if pageNum <= 0 {
+		pageNum = DefaultPageNum
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if exchange == "" {
+		exchange = DefaultExchange
+	}
+
+	return url.Values{
+		"sort":     {p.Sort},
+		"order":    {p.Order},
+		"keyword":  {p.Keyword},
+		"pageNum":  {strconv.Itoa(pageNum)},
+		"pageSize": {strconv.Itoa(pageSize)},
+		"ex":       {exchange},
+	}
+}
+
+// OpenInterestChartParams 持仓量图表请求参数
+// 📋 对应 /api/openInterest/v3/chart 的查询参数，零值字段使用默认值
+type OpenInterestChartParams struct {
+	Symbol       string // 🪙 币种符号（默认BTC）
+	TimeType     int    // ⏰ 时间类型
+	ExchangeName string // 🏢 交易所名称（空表示全部）
+	Currency     string // 💵 计价货币（默认USD）
+	Type         int    // 📈 数据类型
+}
+
+// values 转换为URL查询参数
+func (p OpenInterestChartParams) values() url.Values {
+	symbol, currency := p.Symbol, p.Currency
+	if symbol == "" {
+		symbol = DefaultSymbol
+	}
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	return url.Values{
+		"symbol":       {symbol},
+		"timeType":     {strconv.Itoa(p.TimeType)},
+		"exchangeName": {p.ExchangeName},
+		"currency":     {currency},
+		"type":         {strconv.Itoa(p.Type)},
+	}
+}
+
+// CoinMarketsResult 币种市场数据
+type CoinMarketsResult struct {
+	Total    int          `json:"total"`    // 📊 币种总数
+	PageSize int          `json:"pageSize"` // 📄 每页数量
+	List     []CoinMarket `json:"list"`     // 💰 币种列表
+}
+
+// CoinMarket 单个币种的市场数据
+type CoinMarket struct {
+	Symbol                       string  `json:"symbol"`                       // 🪙 币种符号
+	SymbolLogo                   string  `json:"symbolLogo"`                   // 🖼️ 币种图标
+	Price                        float64 `json:"price"`                        // 💵 当前价格
+	PriceChangePercent24h        float64 `json:"priceChangePercent24h"`        // 📈 24小时涨跌幅
+	MarketCap                    float64 `json:"marketCap"`                    // 🏦 市值
+	OpenInterest                 float64 `json:"openInterest"`                 // 📊 持仓量
+	OpenInterestChangePercent24h float64 `json:"openInterestChangePercent24h"` // 📊 24小时持仓变化
+	VolUsd                       float64 `json:"volUsd"`                       // 💹 24小时成交额
+	AvgFundingRateByOi           float64 `json:"avgFundingRateByOi"`           // 💸 持仓加权资金费率
+	AvgFundingRateByOiAPR        float64 `json:"avgFundingRateByOiAPR"`        // 💸 持仓加权资金费率年化
+	AvgFundingRateByVol          float64 `json:"avgFundingRateByVol"`          // 💸 成交量加权资金费率
+}
+
+// OpenInterestChart 持仓量图表数据
+// 📈 DateList、PriceList与DataMap中每个交易所的序列按下标一一对应
+type OpenInterestChart struct {
+	DateList  []int64              `json:"dateList"`  // ⏰ 时间戳序列（毫秒）
+	PriceList []float64            `json:"priceList"` // 💵 价格序列
+	DataMap   map[string][]float64 `json:"dataMap"`   // 🏢 交易所 -> 持仓量序列
+}
+
+// FuturesHomeStatistics 期货首页统计数据
+type FuturesHomeStatistics struct {
+	OpenInterest float64 `json:"openInterest"` // 📊 全网持仓量
+	OiH24Chain   float64 `json:"oiH24Chain"`   // 📊 持仓量24小时环比
+	VolUsd       float64 `json:"volUsd"`       // 💹 24小时成交额
+	VolH24Chain  float64 `json:"volH24Chain"`  // 💹 成交额24小时环比
+	LongRate     float64 `json:"longRate"`     // 🟢 多头占比
+	ShortRate    float64 `json:"shortRate"`    // 🔴 空头占比
+}
+
+// DerivativeExchange 衍生品交易所信息
+type DerivativeExchange struct {
+	ExchangeName      string  `json:"exchangeName"`      // 🏢 交易所名称
+	Logo              string  `json:"logo"`              // 🖼️ 交易所图标
+	OpenInterest      float64 `json:"openInterest"`      // 📊 持仓量
+	VolUsd            float64 `json:"volUsd"`            // 💹 24小时成交额
+	LiquidationVolUsd float64 `json:"liquidationVolUsd"` // 💥 24小时爆仓额
+}
+
+// FuturesPair 期货交易对信息
+type FuturesPair struct {
+	Symbol     string `json:"symbol"`     // 🪙 币种符号
+	SymbolLogo string `json:"symbolLogo"` // 🖼️ 币种图标
+}
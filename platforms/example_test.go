@@ -0,0 +1,65 @@
+package platforms
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// echoPlatform 测试用平台，原样返回请求目标
+type echoPlatform struct {
+	name string
+}
+
+func (p echoPlatform) Name() string { return p.name }
+
+func (p echoPlatform) Capabilities() []Capability {
+	return []Capability{{Action: "echo", Description: "原样返回", Target: "任意字符串"}}
+}
+
+func (p echoPlatform) Fetch(ctx context.Context, req Request) (Result, error) {
+	return Result{Platform: p.name, Action: req.Action, Data: req.Target}, nil
+}
+
+// TestRegistry 测试平台注册、查找与调用
+func TestRegistry(t *testing.T) {
+	Register(echoPlatform{name: "echo-test"})
+
+	if _, ok := Lookup("echo-test"); !ok {
+		t.Fatal("❌ 注册后应能查找到平台")
+	}
+
+	found := false
+	for _, p := range List() {
+		if p.Name() == "echo-test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("❌ List应包含已注册平台")
+	}
+
+	result, err := Fetch(context.Background(), "echo-test", Request{Action: "echo", Target: "hello"})
+	if err != nil || result.Data != "hello" {
+		t.Fatalf("❌ Fetch结果不符: %+v, %v", result, err)
+	}
+
+	if _, err := Fetch(context.Background(), "echo-test", Request{Action: "unknown"}); !errors.Is(err, ErrUnsupportedAction) {
+		t.Fatalf("❌ 期望ErrUnsupportedAction，实际: %v", err)
+	}
+	if _, err := Fetch(context.Background(), "missing", Request{Action: "echo"}); !errors.Is(err, ErrUnknownPlatform) {
+		t.Fatalf("❌ 期望ErrUnknownPlatform，实际: %v", err)
+	}
+}
+
+// TestRegisterDuplicate 测试重复注册会panic
+func TestRegisterDuplicate(t *testing.T) {
+	Register(echoPlatform{name: "dup-test"})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("❌ 重复注册应panic")
+		}
+	}()
+	Register(echoPlatform{name: "dup-test"})
+}
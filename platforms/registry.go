@@ -0,0 +1,138 @@
+// Package platforms 平台注册中心
+//
+// 🧩 各平台包在 init 中调用 Register 注册自身，调用方只需匿名导入平台包，
+// 即可通过统一的 Fetch(ctx, Request) (Result, error) 入口调用任意平台，
+// 无需针对每个平台编写特殊逻辑。
+//
+// 使用示例:
+//
+//	import (
+//		"github.com/xieburoucoco/spider-hub/platforms"
+//		_ "github.com/xieburoucoco/spider-hub/platforms/amazon"
+//		_ "github.com/xieburoucoco/spider-hub/platforms/coinglass"
+//	)
+//
+//	result, err := platforms.Fetch(ctx, "coinglass", platforms.Request{
+//		Action: "get",
+//		Target: "/api/spot/support/coin",
+//	})
+package platforms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownPlatform 平台未注册
+var ErrUnknownPlatform = errors.New("未注册的平台")
+
+// ErrUnsupportedAction 平台不支持该操作
+var ErrUnsupportedAction = errors.New("平台不支持该操作")
+
+// Capability 平台能力描述
+type Capability struct {
+	Action      string `json:"action"`      // 🎯 操作名称，对应Request.Action
+	Description string `json:"description"` // 📝 操作说明
+	Target      string `json:"target"`      // 🔗 Request.Target的含义
+}
+
+// Request 统一的抓取请求
+type Request struct {
+	Action string            // 🎯 操作名称，必须是平台Capabilities之一
+	Target string            // 🔗 操作对象：URL、接口路径、ASIN等
+	Params map[string]string // 📋 附加参数
+	Body   []byte            // 📦 二进制数据，如图片搜索的图片内容
+}
+
+// Param 读取参数，不存在时返回def
+func (r Request) Param(key, def string) string {
+	if value, ok := r.Params[key]; ok && value != "" {
+		return value
+	}
+	return def
+}
+
+// Result 统一的抓取结果
+type Result struct {
+	Platform string      `json:"platform"` // 🏷️ 平台名称
+	Action   string      `json:"action"`   // 🎯 操作名称
+	Data     interface{} `json:"data"`     // 📊 平台返回的类型化数据
+}
+
+// Platform 平台统一接口
+type Platform interface {
+	// Name 平台名称，全局唯一
+	Name() string
+	// Capabilities 平台支持的操作列表
+	Capabilities() []Capability
+	// Fetch 执行抓取请求
+	Fetch(ctx context.Context, req Request) (Result, error)
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Platform)
+)
+
+// Register 注册平台
+// ⚠️ 平台为nil或名称重复时panic，与database/sql的驱动注册保持一致
+func Register(p Platform) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if p == nil {
+		panic("platforms: Register的平台为nil")
+	}
+	name := p.Name()
+	if _, dup := registry[name]; dup {
+		panic("platforms: 重复注册平台 " + name)
+	}
+	registry[name] = p
+}
+
+// Lookup 按名称查找平台
+func Lookup(name string) (Platform, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	p, ok := registry[name]
+	return p, ok
+}
+
+// List 返回所有已注册平台，按名称排序
+func List() []Platform {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]Platform, 0, len(registry))
+	for _, p := range registry {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
+
+// Supports 判断平台是否支持指定操作
+func Supports(p Platform, action string) bool {
+	for _, c := range p.Capabilities() {
+		if c.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch 通过平台名称执行抓取请求
+func Fetch(ctx context.Context, name string, req Request) (Result, error) {
+	p, ok := Lookup(name)
+	if !ok {
+		return Result{}, fmt.Errorf("❌ %w: %s", ErrUnknownPlatform, name)
+	}
+	if !Supports(p, req.Action) {
+		return Result{}, fmt.Errorf("❌ %w: %s %s", ErrUnsupportedAction, name, req.Action)
+	}
+	return p.Fetch(ctx, req)
+}
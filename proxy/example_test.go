@@ -0,0 +1,289 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 可控的时钟，用于测试冷却时间
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// newTestPool 创建使用模拟时钟的代理池
+func newTestPool(source Source) (*RotatingPool, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	pool := NewRotatingPool(source)
+	pool.now = clock.now
+	return pool, clock
+}
+
+// mustGet 获取代理，失败时终止测试
+func mustGet(t *testing.T, pool *RotatingPool, ctx context.Context) string {
+	t.Helper()
+	u, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatalf("❌ 获取代理失败: %v", err)
+	}
+	return u
+}
+
+// TestRoundRobin 测试轮询选择
+func TestRoundRobin(t *testing.T) {
+	pool := NewStaticPool("http://a:1", "b:2", "http://c:3")
+	ctx := context.Background()
+
+	want := []string{"http://a:1", "http://b:2", "http://c:3", "http://a:1"}
+	for i, w := range want {
+		if got := mustGet(t, pool, ctx); got != w {
+			t.Fatalf("❌ 第%d次期望 %s，实际 %s", i+1, w, got)
+		}
+	}
+}
+
+// TestWeighted 测试平滑加权轮询
+func TestWeighted(t *testing.T) {
+	pool := NewRotatingPool(StaticSource{{URL: "http://a:1", Weight: 3}, {URL: "http://b:2", Weight: 1}}).
+		SetStrategy(Weighted)
+	ctx := context.Background()
+
+	counts := make(map[string]int)
+	var order []string
+	for i := 0; i < 8; i++ {
+		u := mustGet(t, pool, ctx)
+		counts[u]++
+		order = append(order, u)
+	}
+	if counts["http://a:1"] != 6 || counts["http://b:2"] != 2 {
+		t.Fatalf("❌ 权重分布错误: %v", counts)
+	}
+	// 平滑加权：高权重代理不会连续占满一轮
+	if order[0] == order[1] && order[1] == order[2] && order[2] == order[3] {
+		t.Fatalf("❌ 选择顺序不平滑: %v", order)
+	}
+	t.Logf("✅ 选择顺序: %v", order)
+}
+
+// TestFailureCooldown 测试连续失败后冷却，冷却结束后恢复
+func TestFailureCooldown(t *testing.T) {
+	pool, clock := newTestPool(Static("http://a:1", "http://b:2"))
+	pool.SetMaxFailures(2).SetFailureCooldown(time.Minute)
+	ctx := context.Background()
+	mustGet(t, pool, ctx)
+
+	pool.Report("http://a:1", Failure)
+	pool.Report("http://a:1", Success) // 成功后连续失败评分清零
+	pool.Report("http://a:1", Failure)
+	if !pool.Stats()[0].Available {
+		t.Fatal("❌ 未达到失败阈值不应冷却")
+	}
+	pool.Report("http://a:1", Failure)
+	if pool.Stats()[0].Available {
+		t.Fatal("❌ 连续失败2次应进入冷却")
+	}
+
+	for i := 0; i < 3; i++ {
+		if got := mustGet(t, pool, ctx); got != "http://b:2" {
+			t.Fatalf("❌ 冷却中的代理不应被选中: %s", got)
+		}
+	}
+
+	clock.advance(time.Minute)
+	if !pool.Stats()[0].Available {
+		t.Fatal("❌ 冷却结束后应恢复可用")
+	}
+}
+
+// TestBlocked 测试被封禁的代理立即冷却，全部不可用时返回 ErrNoProxy
+func TestBlocked(t *testing.T) {
+	pool, clock := newTestPool(Static("http://a:1"))
+	pool.SetBlockCooldown(10 * time.Minute)
+	ctx := context.Background()
+
+	u := mustGet(t, pool, ctx)
+	pool.Report(u, Blocked)
+	if _, err := pool.Get(ctx); !errors.Is(err, ErrNoProxy) {
+		t.Fatalf("❌ 期望 ErrNoProxy，实际 %v", err)
+	}
+
+	clock.advance(10 * time.Minute)
+	if got := mustGet(t, pool, ctx); got != u {
+		t.Fatalf("❌ 冷却结束后应恢复: %s", got)
+	}
+	if stat := pool.Stats()[0]; stat.Blocks != 1 {
+		t.Fatalf("❌ 封禁次数错误: %+v", stat)
+	}
+}
+
+// TestStickySession 测试粘性会话
+func TestStickySession(t *testing.T) {
+	pool, clock := newTestPool(Static("http://a:1", "http://b:2", "http://c:3"))
+	pool.SetSessionTTL(time.Hour)
+
+	alice := WithSession(context.Background(), "alice")
+	bob := WithSession(context.Background(), "bob")
+
+	first := mustGet(t, pool, alice)
+	other := mustGet(t, pool, bob)
+	for i := 0; i < 5; i++ {
+		if got := mustGet(t, pool, alice); got != first {
+			t.Fatalf("❌ 同一会话应使用同一代理: %s != %s", got, first)
+		}
+	}
+	if other == first {
+		t.Fatal("❌ 不同会话应轮换到不同代理")
+	}
+
+	// 绑定的代理被封禁后自动切换并重新绑定
+	pool.Report(first, Blocked)
+	switched := mustGet(t, pool, alice)
+	if switched == first || mustGet(t, pool, alice) != switched {
+		t.Fatalf("❌ 封禁后应切换并保持新绑定: %s", switched)
+	}
+
+	// 闲置过期的会话在绑定新会话时被清理
+	clock.advance(2 * time.Hour)
+	mustGet(t, pool, WithSession(context.Background(), "carol"))
+	if _, ok := pool.sessions["bob"]; ok {
+		t.Fatal("❌ 过期会话应被清理")
+	}
+}
+
+// TestFileSource 测试从文件加载代理并刷新
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proxies.txt")
+	content := "# 代理列表\nhttp://a:1\n\n10.0.0.2:8080 3  # 省略协议\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := NewRotatingPool(FileSource(path))
+	ctx := context.Background()
+	mustGet(t, pool, ctx)
+	pool.Report("http://a:1", Failure)
+
+	stats := pool.Stats()
+	if len(stats) != 2 || stats[1].URL != "http://10.0.0.2:8080" || stats[1].Weight != 3 {
+		t.Fatalf("❌ 解析代理文件错误: %+v", stats)
+	}
+
+	// 刷新后保留仍存在代理的健康状态
+	if err := os.WriteFile(path, []byte("http://a:1\nhttp://c:3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Refresh(ctx); err != nil {
+		t.Fatalf("❌ 刷新失败: %v", err)
+	}
+	stats = pool.Stats()
+	if len(stats) != 2 || stats[0].Failures != 1 || stats[1].URL != "http://c:3" {
+		t.Fatalf("❌ 刷新结果错误: %+v", stats)
+	}
+
+	if err := os.WriteFile(path, []byte("http://a:1 abc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Refresh(ctx); err == nil {
+		t.Fatal("❌ 无效权重应返回错误")
+	}
+}
+
+// TestProviderSource 测试从代理服务商接口加载代理
+func TestProviderSource(t *testing.T) {
+	responses := map[string]string{
+		"/strings": `["http://a:1", "b:2"]`,
+		"/objects": `[{"url": "http://a:1", "weight": 2}, {"url": "http://b:2"}]`,
+		"/text":    "http://a:1 2\nhttp://b:2\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	for path := range responses {
+		proxies, err := ProviderSource{URL: server.URL + path}.Load(context.Background())
+		if err != nil || len(proxies) != 2 || proxies[0].URL != "http://a:1" || proxies[1].URL != "http://b:2" {
+			t.Fatalf("❌ %s 解析错误: %+v, %v", path, proxies, err)
+		}
+		if path != "/strings" && proxies[0].Weight != 2 {
+			t.Fatalf("❌ %s 权重解析错误: %+v", path, proxies)
+		}
+	}
+
+	if _, err := NewRotatingPool(ProviderSource{URL: server.URL + "/missing"}).Get(context.Background()); err == nil {
+		t.Fatal("❌ 接口返回404时应返回错误")
+	}
+}
+
+// TestOutcomeFor 测试按状态码判断请求结果
+func TestOutcomeFor(t *testing.T) {
+	cases := []struct {
+		status int
+		err    error
+		want   Outcome
+	}{
+		{200, nil, Success},
+		{404, nil, Success},
+		{403, nil, Blocked},
+		{429, nil, Blocked},
+		{503, nil, Blocked},
+		{502, nil, Failure},
+		{0, errors.New("timeout"), Failure},
+	}
+	for _, c := range cases {
+		if got := OutcomeFor(c.status, c.err); got != c.want {
+			t.Errorf("❌ OutcomeFor(%d, %v) = %d, 期望 %d", c.status, c.err, got, c.want)
+		}
+	}
+}
+
+// TestConcurrentPool 测试并发使用代理池（建议配合 -race 运行）
+func TestConcurrentPool(t *testing.T) {
+	pool := NewStaticPool("http://a:1", "http://b:2", "http://c:3").SetStrategy(Weighted)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := WithSession(context.Background(), fmt.Sprintf("session-%d", i%4))
+			for j := 0; j < 50; j++ {
+				u, err := pool.Get(ctx)
+				if err != nil {
+					continue
+				}
+				pool.Report(u, Outcome(j%3))
+			}
+			_ = pool.Refresh(context.Background())
+		}(i)
+	}
+	wg.Wait()
+	t.Logf("✅ 代理状态: %+v", pool.Stats())
+}
+
+// ExampleRotatingPool 代理池基本用法
+func ExampleRotatingPool() {
+	pool := NewStaticPool("http://127.0.0.1:7890", "http://127.0.0.1:7891")
+	ctx := context.Background()
+
+	first, _ := pool.Get(ctx)
+	pool.Report(first, Blocked) // 403/503/验证码：进入冷却
+
+	second, _ := pool.Get(ctx)
+	third, _ := pool.Get(ctx)
+	fmt.Println(first, second, third)
+	// Output: http://127.0.0.1:7890 http://127.0.0.1:7891 http://127.0.0.1:7891
+}
@@ -0,0 +1,180 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 可控的时钟
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// newTestLimiter 创建使用模拟时钟的限速器
+func newTestLimiter(rate float64, burst int) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(rate, burst)
+	l.now = clock.now
+	return l, clock
+}
+
+// TestBurstAndRate 测试突发容量和补充速度
+func TestBurstAndRate(t *testing.T) {
+	l, clock := newTestLimiter(2, 3) // 每500ms一个令牌，突发3个
+
+	var waits []time.Duration
+	for i := 0; i < 5; i++ {
+		waits = append(waits, l.reserve("a"))
+	}
+	want := []time.Duration{0, 0, 0, 500 * time.Millisecond, time.Second}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Fatalf("❌ 等待时间错误: %v，期望 %v", waits, want)
+		}
+	}
+
+	// 闲置足够久后恢复突发容量
+	clock.advance(10 * time.Second)
+	for i := 0; i < 3; i++ {
+		if wait := l.reserve("a"); wait > 0 {
+			t.Fatalf("❌ 闲置后第%d个请求不应等待: %v", i+1, wait)
+		}
+	}
+	if wait := l.reserve("b"); wait > 0 {
+		t.Fatalf("❌ 不同的桶互不影响: %v", wait)
+	}
+}
+
+// TestObserveRetryAfter 测试按 Retry-After 暂停，暂停结束后不允许突发
+func TestObserveRetryAfter(t *testing.T) {
+	l, clock := newTestLimiter(1, 5)
+	l.SetMaxRetryAfter(time.Minute)
+
+	header := http.Header{"Retry-After": []string{"30"}}
+	if d := l.Observe("www.amazon.com", "", http.StatusOK, header); d != 0 {
+		t.Fatalf("❌ 200响应不应暂停: %v", d)
+	}
+	if d := l.Observe("www.amazon.com", "", http.StatusTooManyRequests, header); d != 30*time.Second {
+		t.Fatalf("❌ 暂停时长错误: %v", d)
+	}
+	if wait := l.reserve(l.Key("amazon.com", "")); wait != 30*time.Second {
+		t.Fatalf("❌ 暂停期间应等待到 Retry-After 结束: %v", wait)
+	}
+	if wait := l.reserve(l.Key("amazon.com", "")); wait != 31*time.Second {
+		t.Fatalf("❌ 暂停结束后应按速率放行: %v", wait)
+	}
+
+	// 超过上限时截断
+	header.Set("Retry-After", clock.now().Add(time.Hour).Format(http.TimeFormat))
+	if d := l.Observe("www.amazon.de", "", http.StatusServiceUnavailable, header); d != time.Minute {
+		t.Fatalf("❌ Retry-After 应截断为上限: %v", d)
+	}
+}
+
+// TestScope 测试限速范围
+func TestScope(t *testing.T) {
+	cases := []struct {
+		scope Scope
+		a, b  [2]string // host, proxy
+		same  bool
+	}{
+		{PerHost, [2]string{"www.amazon.com", "http://a:1"}, [2]string{"amazon.com", "http://b:2"}, true},
+		{PerHost, [2]string{"amazon.com", ""}, [2]string{"amazon.de", ""}, false},
+		{PerProxy, [2]string{"amazon.com", "http://a:1"}, [2]string{"amazon.de", "http://a:1"}, true},
+		{PerProxy, [2]string{"amazon.com", "http://a:1"}, [2]string{"amazon.com", "http://b:2"}, false},
+		{PerProxyHost, [2]string{"amazon.com", "http://a:1"}, [2]string{"amazon.de", "http://a:1"}, false},
+		{Global, [2]string{"amazon.com", "http://a:1"}, [2]string{"coinglass.com", ""}, true},
+	}
+	for _, c := range cases {
+		l := New(1, 1).SetScope(c.scope)
+		if same := l.Key(c.a[0], c.a[1]) == l.Key(c.b[0], c.b[1]); same != c.same {
+			t.Errorf("❌ scope=%d %v 与 %v 共用桶=%v，期望 %v", c.scope, c.a, c.b, same, c.same)
+		}
+	}
+}
+
+// TestParseRetryAfter 测试解析 Retry-After
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"Mon, 01 Jan 2024 00:00:10 GMT", 10 * time.Second, true},
+		{"Sun, 31 Dec 2023 23:59:00 GMT", 0, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+	for _, c := range cases {
+		if got, ok := ParseRetryAfter(c.value, now); got != c.want || ok != c.ok {
+			t.Errorf("❌ ParseRetryAfter(%q) = %v %v，期望 %v %v", c.value, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+// TestWait 测试实际等待、抖动和取消
+func TestWait(t *testing.T) {
+	l := New(50, 1).SetJitter(5 * time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(ctx, "amazon.com", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Fatalf("❌ 每秒50次时4个请求至少需要60ms，实际 %v", elapsed)
+	}
+
+	l.Backoff("amazon.com", "", time.Hour)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "amazon.com", ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("❌ 期望 context.DeadlineExceeded，实际 %v", err)
+	}
+}
+
+// TestConcurrentWait 测试并发等待（建议配合 -race 运行）
+func TestConcurrentWait(t *testing.T) {
+	l := New(1000, 10).SetScope(PerProxyHost)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				proxyURL := fmt.Sprintf("http://proxy-%d:1", i%3)
+				if err := l.Wait(context.Background(), "amazon.com", proxyURL); err != nil {
+					t.Error(err)
+					return
+				}
+				l.Observe("amazon.com", proxyURL, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"0"}})
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// ExampleLimiter 限速器基本用法
+func ExampleLimiter() {
+	limiter := New(2, 1) // 每个站点每秒2个请求
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_ = limiter.Wait(ctx, "www.amazon.com", "")
+	}
+	fmt.Println(time.Since(start).Round(500 * time.Millisecond))
+	// Output: 1s
+}
@@ -0,0 +1,221 @@
+// Package ratelimit 令牌桶限速
+//
+// ⏳ 为各平台爬虫限制请求频率：按站点、代理或两者组合分别限速，支持突发容量和随机抖动，
+// 收到 429/503 时按 Retry-After 响应头暂停对应的站点或代理。
+//
+// 使用示例:
+//
+//	limiter := ratelimit.New(2, 5). // 每秒2个请求，突发5个
+//		SetJitter(200 * time.Millisecond).
+//		SetScope(ratelimit.PerProxyHost)
+//
+//	spider := amazon.NewAmazonSpider().SetRateLimiter(limiter)
+package ratelimit
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRetryAfter Retry-After 的默认上限，避免异常的响应头长时间阻塞请求
+const DefaultMaxRetryAfter = 5 * time.Minute
+
+// Scope 限速范围，决定哪些请求共用同一个令牌桶
+type Scope int
+
+const (
+	PerHost      Scope = iota // 🌐 每个站点独立限速（默认）
+	PerProxy                  // 🔄 每个代理（出口IP）独立限速，不使用代理的请求共用一个桶
+	PerProxyHost              // 🧩 每个代理访问每个站点独立限速
+	Global                    // 🌍 所有请求共用一个桶
+)
+
+// Limiter 令牌桶限速器，可在多个goroutine中并发使用
+//
+// 🪣 每个桶以 rate 的速度补充令牌，最多积累 burst 个；
+// 令牌不足时 Wait 阻塞到轮到本次请求为止，等待的请求按到达顺序依次放行
+type Limiter struct {
+	rate          float64
+	burst         int
+	jitter        time.Duration
+	scope         Scope
+	maxRetryAfter time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+
+	// now 当前时间，测试时可替换
+	now func() time.Time
+}
+
+// bucket 单个令牌桶
+// 📐 记录理论上下一个请求的到达时间（GCRA算法），等价于令牌桶且无需定时补充
+type bucket struct {
+	tat time.Time
+}
+
+// New 创建限速器
+//
+// rate 为每秒请求数，<=0 表示不限速（仍会遵守 Retry-After）；burst 为突发容量，<=0 时为1
+func New(rate float64, burst int) *Limiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:          rate,
+		burst:         burst,
+		maxRetryAfter: DefaultMaxRetryAfter,
+		buckets:       make(map[string]*bucket),
+		now:           time.Now,
+	}
+}
+
+// SetJitter 设置随机抖动，每次请求额外等待 [0, jitter) 的随机时间，避免请求间隔过于规律
+func (l *Limiter) SetJitter(jitter time.Duration) *Limiter {
+	l.jitter = jitter
+	return l
+}
+
+// SetScope 设置限速范围（默认 PerHost）
+func (l *Limiter) SetScope(scope Scope) *Limiter {
+	l.scope = scope
+	return l
+}
+
+// SetMaxRetryAfter 设置 Retry-After 的上限（默认 DefaultMaxRetryAfter），<=0 表示忽略 Retry-After
+func (l *Limiter) SetMaxRetryAfter(d time.Duration) *Limiter {
+	l.maxRetryAfter = d
+	return l
+}
+
+// Key 返回请求所属令牌桶的键
+func (l *Limiter) Key(host, proxyURL string) string {
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	switch l.scope {
+	case PerProxy:
+		return proxyURL
+	case PerProxyHost:
+		return proxyURL + "|" + host
+	case Global:
+		return ""
+	default:
+		return host
+	}
+}
+
+// Wait 等待直到允许向 host 发起请求（经由 proxyURL，不使用代理时为空）
+// 🛑 ctx 取消时立即返回 ctx.Err()
+func (l *Limiter) Wait(ctx context.Context, host, proxyURL string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	wait := l.reserve(l.Key(host, proxyURL))
+	if l.jitter > 0 {
+		wait += rand.N(l.jitter)
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve 为本次请求预约时间，返回需要等待的时长
+func (l *Limiter) reserve(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(key)
+	now := l.now()
+	interval := l.interval()
+
+	// 理论到达时间超前当前时间 burst 个间隔以上时需要等待
+	tat := b.tat
+	if tat.Before(now) {
+		tat = now
+	}
+	b.tat = tat.Add(interval)
+	return max(b.tat.Sub(now)-time.Duration(l.burst)*interval, 0)
+}
+
+// Backoff 暂停向 host（经由 proxyURL）发起请求 d 时长，之后按正常速率放行，不允许突发
+func (l *Limiter) Backoff(host, proxyURL string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(l.Key(host, proxyURL))
+	// 使下一个请求恰好在 until 时放行
+	if tat := l.now().Add(d).Add(time.Duration(l.burst-1) * l.interval()); tat.After(b.tat) {
+		b.tat = tat
+	}
+}
+
+// Observe 根据响应更新限速状态
+// ⏸️ 状态码为 429/503 且带有 Retry-After 响应头时暂停对应的桶，返回暂停时长（不超过上限）
+func (l *Limiter) Observe(host, proxyURL string, statusCode int, header http.Header) time.Duration {
+	if statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	if l.maxRetryAfter <= 0 {
+		return 0
+	}
+	d, ok := ParseRetryAfter(header.Get("Retry-After"), l.now())
+	if !ok {
+		return 0
+	}
+	d = min(d, l.maxRetryAfter)
+	l.Backoff(host, proxyURL, d)
+	return d
+}
+
+// bucket 返回键对应的令牌桶，不存在时创建（调用方需持有锁）
+func (l *Limiter) bucket(key string) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// interval 相邻两次请求的间隔
+func (l *Limiter) interval() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / l.rate)
+}
+
+// ParseRetryAfter 解析 Retry-After 响应头，支持秒数（"120"）和HTTP日期两种格式
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,163 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// fastPolicy 测试用的短间隔策略
+func fastPolicy(attempts int) Policy {
+	return Policy{MaxAttempts: attempts, InitialInterval: time.Millisecond, Multiplier: 2}
+}
+
+// TestDelay 测试指数退避、上限和抖动
+func TestDelay(t *testing.T) {
+	p := Policy{InitialInterval: 100 * time.Millisecond, MaxInterval: time.Second, Multiplier: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if d := p.Delay(i + 1); d != w {
+			t.Errorf("❌ 第%d次重试等待 %v，期望 %v", i+1, d, w)
+		}
+	}
+
+	p.Multiplier = 0
+	if d := p.Delay(5); d != 100*time.Millisecond {
+		t.Errorf("❌ Multiplier<1 时应为固定间隔: %v", d)
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.Delay(1); d < 50*time.Millisecond || d > 150*time.Millisecond {
+			t.Fatalf("❌ 抖动超出范围: %v", d)
+		}
+	}
+}
+
+// TestDo 测试重试次数和不可重试的错误
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+	temporary := errs.NewHTTPError("test", "https://example.com", 503, nil)
+	permanent := errs.NewHTTPError("test", "https://example.com", 404, nil)
+
+	cases := []struct {
+		name     string
+		policy   Policy
+		errs     []error // 每次尝试返回的错误，用完后返回nil
+		attempts int
+		err      error
+	}{
+		{"第一次成功", fastPolicy(3), nil, 1, nil},
+		{"重试后成功", fastPolicy(3), []error{temporary, temporary}, 3, nil},
+		{"达到次数上限", fastPolicy(3), []error{temporary, temporary, temporary, temporary}, 3, temporary},
+		{"不可重试", fastPolicy(3), []error{permanent}, 1, permanent},
+		{"不重试", Never(), []error{temporary}, 1, temporary},
+		{"自定义判断", Policy{MaxAttempts: 3, Retryable: func(err error) bool { return errors.Is(err, errs.ErrNotFound) }}, []error{permanent}, 2, nil},
+	}
+	for _, c := range cases {
+		attempts := 0
+		err := c.policy.Do(ctx, func() error {
+			attempts++
+			if attempts <= len(c.errs) {
+				return c.errs[attempts-1]
+			}
+			return nil
+		})
+		if attempts != c.attempts || err != c.err {
+			t.Errorf("❌ %s: 尝试 %d 次，错误 %v；期望 %d 次，错误 %v", c.name, attempts, err, c.attempts, c.err)
+		}
+	}
+}
+
+// TestDoLimits 测试总耗时上限、上下文取消和 OnRetry
+func TestDoLimits(t *testing.T) {
+	temporary := errs.NewHTTPError("test", "https://example.com", 429, nil)
+
+	// ⏱️ 下一次等待会超过总耗时上限时不再重试
+	attempts := 0
+	p := Policy{MaxAttempts: 10, InitialInterval: 40 * time.Millisecond, Multiplier: 2, MaxElapsed: 100 * time.Millisecond}
+	var delays []time.Duration
+	p.OnRetry = func(attempt int, err error, delay time.Duration) { delays = append(delays, delay) }
+	if err := p.Do(context.Background(), func() error { attempts++; return temporary }); err != temporary {
+		t.Fatalf("❌ 期望返回最后一次的错误，实际 %v", err)
+	}
+	if attempts != 2 || len(delays) != 1 || delays[0] != 40*time.Millisecond {
+		t.Fatalf("❌ 尝试 %d 次，等待 %v", attempts, delays)
+	}
+
+	// 🛑 等待期间取消
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	p = Policy{MaxAttempts: 3, InitialInterval: time.Hour}
+	if err := p.Do(ctx, func() error { return temporary }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("❌ 期望 context.DeadlineExceeded，实际 %v", err)
+	}
+
+	// 🛑 调用方的超时不重试
+	attempts = 0
+	if err := fastPolicy(3).Do(ctx, func() error { attempts++; return ctx.Err() }); attempts != 1 || err == nil {
+		t.Fatalf("❌ 调用方超时后不应重试: %d 次, %v", attempts, err)
+	}
+}
+
+// TestIsRetryable 测试默认的可重试错误判断
+func TestIsRetryable(t *testing.T) {
+	timeout := &url.Error{Op: "Get", URL: "https://example.com", Err: os.ErrDeadlineExceeded}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"请求超时", fmt.Errorf("请求失败: %w", timeout), true},
+		{"连接被重置", reset, true},
+		{"上下文超时", context.DeadlineExceeded, true},
+		{"上下文取消", fmt.Errorf("请求失败: %w", context.Canceled), false},
+		{"429", errs.NewHTTPError("test", "", 429, nil), true},
+		{"502", fmt.Errorf("包装: %w", errs.NewHTTPError("test", "", 502, nil)), true},
+		{"403", errs.NewHTTPError("test", "", 403, nil), false},
+		{"时钟偏差", &errs.DecryptError{Platform: "coinglass", Stage: errs.StageUserHeader, Err: fmt.Errorf("解密user header失败: %w", errs.ErrKeyMismatch)}, true},
+		{"user header编码错误", &errs.DecryptError{Platform: "coinglass", Stage: errs.StageUserHeader, Err: errors.New("illegal base64 data")}, false},
+		{"数据解密失败", &errs.DecryptError{Platform: "coinglass", Stage: errs.StagePayload, Err: errors.New("bad padding")}, false},
+		{"解析失败", &errs.ParseError{Platform: "test", What: "响应", Err: errors.New("unexpected end")}, false},
+		{"其他错误", errors.New("无效的URL"), false},
+	}
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.want {
+			t.Errorf("❌ %s: IsRetryable = %v，期望 %v", c.name, got, c.want)
+		}
+	}
+
+	errStale := errors.New("令牌过期")
+	classify := Any(nil, func(err error) bool { return errors.Is(err, errStale) })
+	if !classify(errStale) || !classify(errs.NewHTTPError("test", "", 503, nil)) || classify(errors.New("其他错误")) {
+		t.Fatal("❌ Any 组合判断错误")
+	}
+}
+
+// ExamplePolicy_Do 重试临时错误
+func ExamplePolicy_Do() {
+	policy := Default()
+	policy.InitialInterval = time.Millisecond
+
+	attempts := 0
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return errs.NewHTTPError("coinglass", "https://capi.coinglass.com/api/spot/support/coin", 503, nil)
+		}
+		return nil
+	})
+	fmt.Println(attempts, err)
+	// Output: 3 <nil>
+}
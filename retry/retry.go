@@ -0,0 +1,165 @@
+// Package retry 统一的重试策略
+//
+// 🔁 指数退避 + 随机抖动，限制最多尝试次数和总耗时，只重试可重试的错误
+// （超时、网络中断、429/5xx、Coinglass动态密钥解密失败等），各平台共用。
+//
+// 使用示例:
+//
+//	policy := retry.Default()
+//	policy.MaxAttempts = 5
+//
+//	err := policy.Do(ctx, func() error {
+//		var err error
+//		data, err = spider.GetDataWithContext(ctx, apiURL)
+//		return err
+//	})
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/xieburoucoco/spider-hub/errs"
+)
+
+// Classifier 判断错误是否可重试
+type Classifier func(err error) bool
+
+// Policy 重试策略
+//
+// ⏳ 第n次重试前等待 InitialInterval * Multiplier^(n-1)，不超过 MaxInterval，
+// 并在 ±Jitter 比例内随机抖动；Policy 为值类型，可复制后修改
+type Policy struct {
+	MaxAttempts     int           // 最多尝试次数（包括第一次），<=1 表示不重试
+	InitialInterval time.Duration // 第一次重试前的等待时间
+	MaxInterval     time.Duration // 单次等待时间上限，0 表示不限
+	Multiplier      float64       // 等待时间增长倍数，<1 时按1处理（固定间隔）
+	Jitter          float64       // 随机抖动比例 [0, 1]，如 0.2 表示在 ±20% 内抖动
+	MaxElapsed      time.Duration // 从第一次尝试开始的总耗时上限，超过时不再重试，0 表示不限
+	Retryable       Classifier    // 可重试错误判断，nil 时使用 IsRetryable
+
+	// OnRetry 每次重试前调用（可选），可用于记录日志
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// Default 默认重试策略：最多尝试3次，等待 500ms、1s，抖动 ±20%，总耗时不超过1分钟
+func Default() Policy {
+	return Policy{
+		MaxAttempts:     3,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxElapsed:      time.Minute,
+	}
+}
+
+// Never 不重试的策略
+func Never() Policy {
+	return Policy{MaxAttempts: 1}
+}
+
+// Delay 返回第 retry 次重试（从1开始）前的等待时间
+func (p Policy) Delay(retry int) time.Duration {
+	multiplier := max(p.Multiplier, 1)
+	delay := float64(p.InitialInterval) * math.Pow(multiplier, float64(max(retry-1, 0)))
+	if p.MaxInterval > 0 {
+		delay = min(delay, float64(p.MaxInterval))
+	}
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// Do 按策略执行fn，直到成功、遇到不可重试的错误或达到次数/耗时上限
+//
+// 返回最后一次的错误；ctx 取消时立即返回 ctx.Err()（等待期间）或fn的错误（执行期间），
+// 调用方的超时和取消不会被当作可重试错误
+func (p Policy) Do(ctx context.Context, fn func() error) error {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || attempt >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+
+		delay := p.Delay(attempt)
+		if p.MaxElapsed > 0 && time.Since(start)+delay > p.MaxElapsed {
+			return err
+		}
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// IsRetryable 默认的可重试错误判断
+//
+// 可重试:
+//   - 超时、连接被重置/拒绝、连接意外关闭
+//   - *errs.HTTPError 且 Temporary()（429、5xx）
+//   - *errs.DecryptError 且 Temporary()（user 响应头密钥不匹配，通常是本地时钟偏差，重试时使用新的时间戳）
+//
+// context.Canceled 不可重试
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var httpErr *errs.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Temporary()
+	}
+	var decryptErr *errs.DecryptError
+	if errors.As(err, &decryptErr) {
+		return decryptErr.Temporary()
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Any 组合多个判断，任意一个返回true即可重试；nil 表示 IsRetryable
+func Any(classifiers ...Classifier) Classifier {
+	return func(err error) bool {
+		for _, classify := range classifiers {
+			if classify == nil {
+				classify = IsRetryable
+			}
+			if classify(err) {
+				return true
+			}
+		}
+		return false
+	}
+}